@@ -0,0 +1,399 @@
+package galoisfield
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"sort"
+	"strings"
+)
+
+var (
+	ErrPolyOverflow = errors.New("binary polynomial product exceeds degree 63")
+)
+
+// BinaryPoly is a polynomial with coefficients in GF(2), i.e. an element of
+// GF(2)[x].  Bit i is the coefficient of x**i, the same encoding as the "p"
+// argument of New; e.g. 0x11d is x**8 + x**4 + x**3 + x**2 + 1.
+type BinaryPoly uint64
+
+// BinaryFactor is an irreducible factor of a BinaryPoly, together with its
+// multiplicity.
+type BinaryFactor struct {
+	Poly         BinaryPoly
+	Multiplicity uint
+}
+
+// Degree returns the degree of p, with the convention that the zero
+// polynomial has degree -1.
+func (p BinaryPoly) Degree() int {
+	return bits.Len64(uint64(p)) - 1
+}
+
+// Add returns p+q, which is the same as p-q.
+func (p BinaryPoly) Add(q BinaryPoly) BinaryPoly { return p ^ q }
+
+// Mul returns p*q.  It panics with ErrPolyOverflow if the product has a degree
+// greater than 63.
+func (p BinaryPoly) Mul(q BinaryPoly) BinaryPoly {
+	if p == 0 || q == 0 {
+		return 0
+	}
+	if p.Degree()+q.Degree() > 63 {
+		panic(ErrPolyOverflow)
+	}
+	var r BinaryPoly
+	for ; q != 0; q >>= 1 {
+		if q&1 != 0 {
+			r ^= p
+		}
+		p <<= 1
+	}
+	return r
+}
+
+// DivMod divides p by q, returning the quotient and the remainder.  It panics
+// with ErrDivByZero if q is zero.
+func (p BinaryPoly) DivMod(q BinaryPoly) (quo, rem BinaryPoly) {
+	if q == 0 {
+		panic(ErrDivByZero)
+	}
+	dq := q.Degree()
+	rem = p
+	for d := rem.Degree(); d >= dq; d = rem.Degree() {
+		quo |= 1 << uint(d-dq)
+		rem ^= q << uint(d-dq)
+	}
+	return quo, rem
+}
+
+// Mod returns the remainder of dividing p by q.  See DivMod for details.
+func (p BinaryPoly) Mod(q BinaryPoly) BinaryPoly {
+	_, rem := p.DivMod(q)
+	return rem
+}
+
+// GCD returns the greatest common divisor of p and q.  Every nonzero binary
+// polynomial is monic, so the result is unique.
+func (p BinaryPoly) GCD(q BinaryPoly) BinaryPoly {
+	for q != 0 {
+		p, q = q, p.Mod(q)
+	}
+	return p
+}
+
+// IsIrreducible returns true iff p has positive degree and no factors other
+// than 1 and itself.  It uses Rabin's test, so it is fast even for degree 63.
+func (p BinaryPoly) IsIrreducible() bool {
+	n := p.Degree()
+	if n < 1 {
+		return false
+	}
+	// p is irreducible iff x**(2**n) == x (mod p), and x**(2**(n/q)) - x is
+	// coprime to p for every prime q dividing n.
+	x := BinaryPoly(2).Mod(p)
+	for _, q := range primeFactors(uint64(n)) {
+		h := x
+		for i := 0; i < n/int(q); i++ {
+			h = mulMod(h, h, p)
+		}
+		if p.GCD(h^x) != 1 {
+			return false
+		}
+	}
+	h := x
+	for i := 0; i < n; i++ {
+		h = mulMod(h, h, p)
+	}
+	return h == x
+}
+
+// IsPrimitive returns true iff p is irreducible and x generates the
+// multiplicative group of GF(2)[x]/p, i.e. iff GF(2**n) built from p has 2
+// among its Generators.
+func (p BinaryPoly) IsPrimitive() bool {
+	if !p.IsIrreducible() || p == 2 {
+		return false
+	}
+	// x has order 2**n - 1 iff no proper divisor of the form (2**n - 1)/q,
+	// for q prime, is a multiple of its order.
+	order := uint64(1)<<uint(p.Degree()) - 1
+	for _, q := range primeFactors(order) {
+		if powMod(2, order/q, p) == 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// Factor returns the irreducible factors of p in increasing order.  The zero
+// polynomial and 1 have no factors, so Factor returns nil for them.
+//
+// Factor uses the classic three stages: square-free factorization,
+// distinct-degree factorization and equal-degree factorization.  The last
+// stage splits by the trace map instead of random choices, so the result is
+// deterministic.
+func (p BinaryPoly) Factor() []BinaryFactor {
+	if p.Degree() < 1 {
+		return nil
+	}
+	var factors []BinaryFactor
+	for _, sf := range squareFree(p) {
+		for _, dd := range distinctDegree(sf.Poly) {
+			for _, f := range equalDegree(dd.Poly, int(dd.Multiplicity)) {
+				factors = append(factors, BinaryFactor{f, sf.Multiplicity})
+			}
+		}
+	}
+	sort.Slice(factors, func(i, j int) bool { return factors[i].Poly < factors[j].Poly })
+	return factors
+}
+
+// String returns p in the "b^8+b^4+b^3+b^2+1" notation used by GF.String.
+func (p BinaryPoly) String() string {
+	if p == 0 {
+		return "0"
+	}
+	var poly []string
+	for i := p.Degree(); i >= 0; i-- {
+		if (p & (1 << uint(i))) != 0 {
+			var mono string
+			if i == 0 {
+				mono = "1"
+			} else if i == 1 {
+				mono = "b"
+			} else {
+				mono = fmt.Sprintf("b^%d", i)
+			}
+			poly = append(poly, mono)
+		}
+	}
+	return strings.Join(poly, "+")
+}
+
+// derivative returns the formal derivative of p.  Only the odd-degree terms
+// survive, each dropping by one degree.
+func (p BinaryPoly) derivative() BinaryPoly {
+	return (p >> 1) & 0x5555555555555555
+}
+
+// squareFree returns the square-free factorization of p: pairwise coprime,
+// square-free polynomials whose powers multiply to p.  The Poly of each entry
+// is the product of every irreducible factor with that multiplicity.
+func squareFree(p BinaryPoly) []BinaryFactor {
+	var result []BinaryFactor
+	c := p.GCD(p.derivative())
+	w, _ := p.DivMod(c)
+	for i := uint(1); w != 1; i++ {
+		y := w.GCD(c)
+		if fac, _ := w.DivMod(y); fac != 1 {
+			result = append(result, BinaryFactor{fac, i})
+		}
+		w = y
+		c, _ = c.DivMod(y)
+	}
+	if c != 1 {
+		// What remains is a perfect square; its square root keeps the
+		// even-degree coefficients.
+		var root BinaryPoly
+		for i := 0; i <= c.Degree(); i += 2 {
+			root |= ((c >> uint(i)) & 1) << uint(i/2)
+		}
+		for _, f := range squareFree(root) {
+			result = append(result, BinaryFactor{f.Poly, 2 * f.Multiplicity})
+		}
+	}
+	return result
+}
+
+// distinctDegree splits a square-free p into products of irreducible factors
+// of equal degree.  The Multiplicity of each entry holds that degree.
+func distinctDegree(p BinaryPoly) []BinaryFactor {
+	var result []BinaryFactor
+	x := BinaryPoly(2)
+	h := x.Mod(p)
+	for d := 1; p.Degree() >= 2*d; d++ {
+		// h == x**(2**d) mod p, whose difference with x is the product of
+		// every irreducible polynomial of degree dividing d.
+		h = mulMod(h, h, p)
+		if g := p.GCD(h ^ x); g != 1 {
+			result = append(result, BinaryFactor{g, uint(d)})
+			p, _ = p.DivMod(g)
+			h = h.Mod(p)
+		}
+	}
+	if p != 1 {
+		result = append(result, BinaryFactor{p, uint(p.Degree())})
+	}
+	return result
+}
+
+// equalDegree splits p, a product of distinct irreducible polynomials of
+// degree d, into those polynomials.
+func equalDegree(p BinaryPoly, d int) []BinaryPoly {
+	if p.Degree() <= d {
+		return []BinaryPoly{p}
+	}
+	// The trace a + a**2 + ... + a**(2**(d-1)) is 0 or 1 modulo each factor.
+	// It is linear in a, so some power of x must tell two factors apart.
+	for j := 1; j < p.Degree(); j++ {
+		a := BinaryPoly(1) << uint(j)
+		t, s := a, a
+		for i := 1; i < d; i++ {
+			s = mulMod(s, s, p)
+			t ^= s
+		}
+		g := p.GCD(t)
+		if g != 1 && g != p {
+			q, _ := p.DivMod(g)
+			return append(equalDegree(g, d), equalDegree(q, d)...)
+		}
+	}
+	panic("unreachable: equal-degree factorization did not split")
+}
+
+// mulMod returns a*b mod m, where a and b are already reduced modulo m.
+func mulMod(a, b, m BinaryPoly) BinaryPoly {
+	top := BinaryPoly(1) << uint(m.Degree())
+	var r BinaryPoly
+	for ; b != 0; b >>= 1 {
+		if b&1 != 0 {
+			r ^= a
+		}
+		a <<= 1
+		if a&top != 0 {
+			a ^= m
+		}
+	}
+	return r
+}
+
+// powMod returns a**e mod m.
+func powMod(a BinaryPoly, e uint64, m BinaryPoly) BinaryPoly {
+	a = a.Mod(m)
+	r := BinaryPoly(1).Mod(m)
+	for ; e != 0; e >>= 1 {
+		if e&1 != 0 {
+			r = mulMod(r, a, m)
+		}
+		a = mulMod(a, a, m)
+	}
+	return r
+}
+
+// primeFactors returns the distinct prime factors of n in increasing order.
+// Small factors are found by trial division, and the rest by Pollard's rho
+// with a deterministic Miller-Rabin primality test.
+func primeFactors(n uint64) []uint64 {
+	var factors []uint64
+	for q := uint64(2); q < 1024 && q*q <= n; q++ {
+		if n%q == 0 {
+			factors = append(factors, q)
+			for n%q == 0 {
+				n /= q
+			}
+		}
+	}
+	var split func(n uint64)
+	split = func(n uint64) {
+		if n == 1 {
+			return
+		}
+		if isPrime64(n) {
+			for _, q := range factors {
+				if q == n {
+					return
+				}
+			}
+			factors = append(factors, n)
+			return
+		}
+		d := pollardRho(n)
+		split(d)
+		split(n / d)
+	}
+	split(n)
+	sort.Slice(factors, func(i, j int) bool { return factors[i] < factors[j] })
+	return factors
+}
+
+// isPrime64 returns true iff n is prime.  These Miller-Rabin bases are known
+// to be sufficient for every n < 2**64.
+func isPrime64(n uint64) bool {
+	if n < 2 {
+		return false
+	}
+	bases := []uint64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37}
+	for _, b := range bases {
+		if n%b == 0 {
+			return n == b
+		}
+	}
+	d, s := n-1, 0
+	for d%2 == 0 {
+		d /= 2
+		s++
+	}
+	for _, b := range bases {
+		x := powMod64(b, d, n)
+		if x == 1 || x == n-1 {
+			continue
+		}
+		composite := true
+		for i := 1; i < s; i++ {
+			x = mulMod64(x, x, n)
+			if x == n-1 {
+				composite = false
+				break
+			}
+		}
+		if composite {
+			return false
+		}
+	}
+	return true
+}
+
+// pollardRho returns a nontrivial factor of the odd composite n.
+func pollardRho(n uint64) uint64 {
+	for c := uint64(1); ; c++ {
+		f := func(x uint64) uint64 { return (mulMod64(x, x, n) + c) % n }
+		x, y, d := uint64(2), uint64(2), uint64(1)
+		for d == 1 {
+			x = f(x)
+			y = f(f(y))
+			if x > y {
+				d = gcd64(x-y, n)
+			} else {
+				d = gcd64(y-x, n)
+			}
+		}
+		if d != n {
+			return d
+		}
+	}
+}
+
+func mulMod64(a, b, m uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	_, r := bits.Div64(hi%m, lo, m)
+	return r
+}
+
+func powMod64(a, e, m uint64) uint64 {
+	r := uint64(1)
+	for a %= m; e != 0; e >>= 1 {
+		if e&1 != 0 {
+			r = mulMod64(r, a, m)
+		}
+		a = mulMod64(a, a, m)
+	}
+	return r
+}
+
+func gcd64(a, b uint64) uint64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
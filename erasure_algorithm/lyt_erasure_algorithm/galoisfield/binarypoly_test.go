@@ -0,0 +1,193 @@
+package galoisfield
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestBinaryPoly_arithmetic(t *testing.T) {
+	if d := BinaryPoly(0).Degree(); d != -1 {
+		t.Errorf("expected degree -1 for 0, got %d", d)
+	}
+	if d := BinaryPoly(0x11d).Degree(); d != 8 {
+		t.Errorf("expected degree 8, got %d", d)
+	}
+	// (x + 1)(x^2 + x + 1) = x^3 + 1
+	if p := BinaryPoly(3).Mul(7); p != 9 {
+		t.Errorf("expected 0x9, got %#x", uint64(p))
+	}
+
+	prng := rand.New(rand.NewSource(42))
+	for trial := 0; trial < 1024; trial++ {
+		a := BinaryPoly(prng.Uint64() >> uint(32+prng.Intn(32)))
+		b := BinaryPoly(prng.Uint64()>>uint(33+prng.Intn(31))) | 1
+		q, r := a.DivMod(b)
+		if q.Mul(b).Add(r) != a || r.Degree() >= b.Degree() {
+			t.Errorf("%v / %v: got %v rem %v", a, b, q, r)
+		}
+		c := BinaryPoly(prng.Uint64()>>56) | 1
+		g := a.Mul(c).GCD(b.Mul(c))
+		if a.Mul(c).Mod(g) != 0 || b.Mul(c).Mod(g) != 0 || g.Mod(c) != 0 {
+			t.Errorf("gcd(%v, %v) = %v", a.Mul(c), b.Mul(c), g)
+		}
+	}
+
+	e := panicValue(func() { BinaryPoly(1 << 40).Mul(1 << 24) })
+	if e != ErrPolyOverflow {
+		t.Errorf("expected ErrPolyOverflow, got %v", e)
+	}
+	e = panicValue(func() { BinaryPoly(7).DivMod(0) })
+	if e != ErrDivByZero {
+		t.Errorf("expected ErrDivByZero, got %v", e)
+	}
+}
+
+func TestBinaryPoly_String(t *testing.T) {
+	for p, expect := range map[BinaryPoly]string{
+		0:     "0",
+		1:     "1",
+		2:     "b",
+		0x11d: "b^8+b^4+b^3+b^2+1",
+	} {
+		if s := p.String(); s != expect {
+			t.Errorf("%#x: expected %q, got %q", uint64(p), expect, s)
+		}
+	}
+}
+
+func TestBinaryPoly_IsIrreducible(t *testing.T) {
+	// The number of irreducible and primitive binary polynomials of degree
+	// d; the latter is Euler's totient of 2**d-1, divided by d.
+	irreducible := []int{0, 2, 1, 2, 3, 6, 9, 18, 30, 56, 99, 186, 335}
+	primitive := []int{0, 1, 1, 2, 2, 6, 6, 18, 16, 48, 60, 176, 144}
+	for d := 1; d < len(irreducible); d++ {
+		var ni, np int
+		for p := BinaryPoly(1) << uint(d); p < 2<<uint(d); p++ {
+			if p.IsIrreducible() {
+				ni++
+				if d <= 8 && !bruteIrreducible(p) {
+					t.Errorf("%v: expected reducible", p)
+				}
+			} else if d <= 8 && bruteIrreducible(p) {
+				t.Errorf("%v: expected irreducible", p)
+			}
+			if p.IsPrimitive() {
+				np++
+				if !p.IsIrreducible() {
+					t.Errorf("%v: primitive but reducible", p)
+				}
+			}
+		}
+		if ni != irreducible[d] || np != primitive[d] {
+			t.Errorf("degree %d: expected %d irreducible and %d primitive, got %d and %d",
+				d, irreducible[d], primitive[d], ni, np)
+		}
+	}
+
+	// IsPrimitive agrees with Generators.
+	for _, wk := range wellknown[1:] {
+		gens, _ := Generators(wk.field.Size(), wk.field.Polynomial())
+		is2 := len(gens) > 0 && gens[0] == 2
+		if BinaryPoly(wk.field.Polynomial()).IsPrimitive() != is2 {
+			t.Errorf("%s: IsPrimitive disagrees with Generators %v", wk.name, gens)
+		}
+	}
+
+	for _, row := range []struct {
+		p         BinaryPoly
+		irr, prim bool
+	}{
+		{0, false, false},
+		{1, false, false},
+		{2, true, false},
+		{3, true, true},
+		{0x11b, true, false},
+		{0x11d, true, true},
+		{0x1100b, true, true},
+		{1<<63 | 3, true, true}, // x^63 + x + 1
+		{1<<61 | 1<<5 | 1<<2 | 1<<1 | 1, true, true},
+		{BinaryPoly(1<<31 | 1<<3 | 1).Mul(3), false, false},
+	} {
+		if irr := row.p.IsIrreducible(); irr != row.irr {
+			t.Errorf("%v: expected IsIrreducible=%v, got %v", row.p, row.irr, irr)
+		}
+		if prim := row.p.IsPrimitive(); prim != row.prim {
+			t.Errorf("%v: expected IsPrimitive=%v, got %v", row.p, row.prim, prim)
+		}
+	}
+}
+
+func TestBinaryPoly_Factor(t *testing.T) {
+	type testrow struct {
+		p      BinaryPoly
+		expect []BinaryFactor
+	}
+	for _, row := range []testrow{
+		testrow{0, nil},
+		testrow{1, nil},
+		testrow{0x11d, []BinaryFactor{{0x11d, 1}}},
+		// x^2 + 1 = (x + 1)^2
+		testrow{5, []BinaryFactor{{3, 2}}},
+		// x^4 + x = x (x + 1) (x^2 + x + 1)
+		testrow{0x12, []BinaryFactor{{2, 1}, {3, 1}, {7, 1}}},
+	} {
+		factors := row.p.Factor()
+		if len(factors) != len(row.expect) {
+			t.Errorf("%v: expected %v, got %v", row.p, row.expect, factors)
+			continue
+		}
+		for i := range factors {
+			if factors[i] != row.expect[i] {
+				t.Errorf("%v: expected %v, got %v", row.p, row.expect, factors)
+				break
+			}
+		}
+	}
+
+	prng := rand.New(rand.NewSource(42))
+	for trial := 0; trial < 256; trial++ {
+		// Multiply random small polynomials, repeating some of them.
+		p := BinaryPoly(1)
+		for p.Degree() < 40 {
+			f := BinaryPoly(prng.Intn(1<<uint(1+prng.Intn(12)))) | 2
+			for n := 1 + prng.Intn(3); n > 0 && p.Degree()+f.Degree() <= 63; n-- {
+				p = p.Mul(f)
+			}
+		}
+		checkFactors(t, p, p.Factor())
+	}
+	p := BinaryPoly(1<<63 | 1<<62 | 1<<7 | 1)
+	checkFactors(t, p, p.Factor())
+}
+
+func checkFactors(t *testing.T, p BinaryPoly, factors []BinaryFactor) {
+	t.Helper()
+	prod := BinaryPoly(1)
+	for i, f := range factors {
+		if !f.Poly.IsIrreducible() {
+			t.Errorf("%v: factor %v is reducible", p, f.Poly)
+		}
+		if i > 0 && factors[i-1].Poly >= f.Poly {
+			t.Errorf("%v: factors out of order: %v", p, factors)
+		}
+		for n := uint(0); n < f.Multiplicity; n++ {
+			prod = prod.Mul(f.Poly)
+		}
+	}
+	if prod != p {
+		t.Errorf("%v: factors %v multiply to %v", p, factors, prod)
+	}
+}
+
+// bruteIrreducible checks p by trial division.
+func bruteIrreducible(p BinaryPoly) bool {
+	if p.Degree() < 1 {
+		return false
+	}
+	for q := BinaryPoly(2); q.Degree() <= p.Degree()/2; q++ {
+		if p.Mod(q) == 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,94 @@
+package galoisfield
+
+import (
+	"math/bits"
+)
+
+// ConstantTimeGF performs the same arithmetic as the GF it was derived from,
+// but without table lookups or branches on the operands, so that its running
+// time does not depend on the (possibly secret) values involved.  It is much
+// slower than GF and is meant for small amounts of key material, e.g. shares
+// of an encryption key.
+//
+// Mul is computed bit-serially, one bit of the multiplier per step; Inv, Div
+// and Exp are built from Mul with a fixed number of steps.  The one exception
+// is division by zero: like GF, Div and Inv panic with ErrDivByZero, which
+// necessarily reveals whether the divisor is zero.  There is no constant-time
+// Log.
+type ConstantTimeGF struct {
+	params
+}
+
+// ConstantTime returns the constant-time counterpart of gf.
+func (gf *GF) ConstantTime() ConstantTimeGF {
+	return ConstantTimeGF{gf.params}
+}
+
+// Field returns the table-driven GF that computes the same results.
+func (ct ConstantTimeGF) Field() *GF {
+	return MustNew(1<<ct.k, uint(ct.p), ct.g)
+}
+
+// Add returns x+y == x-y == x^y in GF(2**k).
+func (_ ConstantTimeGF) Add(x, y byte) byte { return x ^ y }
+
+// Mul returns x*y in GF(2**k).
+func (ct ConstantTimeGF) Mul(x, y byte) byte {
+	poly := byte(ct.p)
+	var p byte
+	for i := byte(0); i < ct.k; i++ {
+		p ^= -(y & 1) & x
+		y >>= 1
+		carry := -((x >> (ct.k - 1)) & 1)
+		x = (x << 1) ^ (carry & poly)
+	}
+	return p
+}
+
+// Div returns x/y in GF(2**k).
+func (ct ConstantTimeGF) Div(x, y byte) byte {
+	return ct.Mul(x, ct.Inv(y))
+}
+
+// Inv returns 1/x in GF(2**k), computed as x**(2**k - 2).
+func (ct ConstantTimeGF) Inv(x byte) byte {
+	if x == 0 {
+		panic(ErrDivByZero)
+	}
+	// The exponent is public, so branching on its bits leaks nothing.
+	e := uint(1)<<ct.k - 2
+	var r byte = 1
+	for i := int(ct.k) - 1; i >= 0; i-- {
+		r = ct.Mul(r, r)
+		if (e>>uint(i))&1 != 0 {
+			r = ct.Mul(r, x)
+		}
+	}
+	return r
+}
+
+// Exp returns g**x in GF(2**k).
+func (ct ConstantTimeGF) Exp(x byte) byte {
+	m := uint(1)<<ct.k - 1
+
+	// Reduce x mod m by binary long division.  x < 256 and m ≥ 3, so the
+	// quotient fits in 7 bits.
+	e := uint(x)
+	for s := 6; s >= 0; s-- {
+		diff := e - m<<uint(s)
+		borrow := diff >> (bits.UintSize - 1)
+		keep := borrow - 1
+		e = (diff & keep) | (e &^ keep)
+	}
+
+	// Square-and-multiply over the bits of e, selecting rather than
+	// branching on each bit.
+	var r byte = 1
+	for i := int(ct.k) - 1; i >= 0; i-- {
+		r = ct.Mul(r, r)
+		t := ct.Mul(r, ct.g)
+		mask := -byte((e >> uint(i)) & 1)
+		r = (t & mask) | (r &^ mask)
+	}
+	return r
+}
@@ -0,0 +1,68 @@
+package galoisfield
+
+import (
+	"testing"
+)
+
+func TestConstantTimeGF(t *testing.T) {
+	for _, wk := range wellknown[1:] {
+		gf := wk.field
+		ct := gf.ConstantTime()
+		if ct.Field() != gf {
+			t.Errorf("%s: expected Field() to return %#v, got %#v", wk.name, gf, ct.Field())
+		}
+		n := gf.Size()
+		for x := uint(0); x < n; x++ {
+			a := byte(x)
+			for y := uint(0); y < n; y++ {
+				b := byte(y)
+				if actual, expect := ct.Add(a, b), gf.Add(a, b); actual != expect {
+					t.Errorf("%s: expected %d+%d=%d, got %d", wk.name, a, b, expect, actual)
+				}
+				if actual, expect := ct.Mul(a, b), gf.Mul(a, b); actual != expect {
+					t.Errorf("%s: expected %d*%d=%d, got %d", wk.name, a, b, expect, actual)
+				}
+				if b != 0 {
+					if actual, expect := ct.Div(a, b), gf.Div(a, b); actual != expect {
+						t.Errorf("%s: expected %d/%d=%d, got %d", wk.name, a, b, expect, actual)
+					}
+				}
+			}
+			if a != 0 {
+				if actual, expect := ct.Inv(a), gf.Inv(a); actual != expect {
+					t.Errorf("%s: expected 1/%d=%d, got %d", wk.name, a, expect, actual)
+				}
+			}
+		}
+		for x := 0; x < 256; x++ {
+			if actual, expect := ct.Exp(byte(x)), gf.Exp(byte(x)); actual != expect {
+				t.Errorf("%s: expected Exp(%d)=%d, got %d", wk.name, x, expect, actual)
+			}
+		}
+	}
+}
+
+func TestConstantTimeGF_Div_zero(t *testing.T) {
+	ct := Default.ConstantTime()
+	e := panicValue(func() {
+		ct.Div(1, 0)
+	})
+	if e != ErrDivByZero {
+		t.Errorf("expected panic(ErrDivByZero), got %v", e)
+	}
+	e = panicValue(func() {
+		ct.Inv(0)
+	})
+	if e != ErrDivByZero {
+		t.Errorf("expected panic(ErrDivByZero), got %v", e)
+	}
+}
+
+func BenchmarkConstantTimeGF_Mul_256(b *testing.B) {
+	ct := Default.ConstantTime()
+	var x byte = 1
+	var y byte = 3
+	for i := 0; i < b.N; i++ {
+		_ = ct.Mul(x, y)
+	}
+}
@@ -0,0 +1,124 @@
+package galoisfield
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+)
+
+// DefaultDecodeCacheSize is the number of bytes of decode matrices that
+// Raid6New caches.  A decode matrix for d data shards takes d*d bytes, or
+// 2*d*d bytes for a stripe wider than 256 shards.
+const DefaultDecodeCacheSize = 16 << 20
+
+// CacheStats reports the activity of a decode-matrix cache.
+type CacheStats struct {
+	Hits    uint64 // lookups answered from the cache
+	Misses  uint64 // lookups that had to invert a matrix
+	Entries int    // matrices currently cached
+	Bytes   int    // total size of the matrices currently cached
+	Limit   int    // maximum total size of the matrices cached
+}
+
+// shardBitmap records which shards were used to build a decode matrix; bit i
+// is set iff shard i was valid.  It is a string so that it can key a map
+// however wide the stripe is.
+type shardBitmap string
+
+// newShardBitmap returns the bitmap of indices, which must be sorted.
+func newShardBitmap(indices []int) shardBitmap {
+	if len(indices) == 0 {
+		return ""
+	}
+	b := make([]byte, indices[len(indices)-1]/8+1)
+	for _, i := range indices {
+		b[i/8] |= 1 << uint(i%8)
+	}
+	return shardBitmap(b)
+}
+
+// decodeCache is a concurrency-safe LRU cache of inverted decode matrices,
+// keyed by the shards that were valid when each was built, and bounded by the
+// total size of the matrices in bytes.  Each value is a
+// Matrix or a Matrix65536, depending on the field.  The cached matrices are
+// shared by every caller, so they MUST NOT be modified.
+type decodeCache struct {
+	// hits and misses come first to keep them 64-bit aligned for atomic
+	// access on 32-bit platforms.
+	hits   uint64
+	misses uint64
+
+	mu      sync.Mutex
+	limit   int
+	bytes   int
+	lru     *list.List // of *decodeCacheEntry, most recently used first
+	entries map[shardBitmap]*list.Element
+}
+
+type decodeCacheEntry struct {
+	key  shardBitmap
+	m    interface{}
+	size int
+}
+
+func newDecodeCache(limit int) *decodeCache {
+	return &decodeCache{
+		limit:   limit,
+		lru:     list.New(),
+		entries: make(map[shardBitmap]*list.Element),
+	}
+}
+
+// get returns the matrix cached for key, or nil.
+func (c *decodeCache) get(key shardBitmap) interface{} {
+	c.mu.Lock()
+	e, found := c.entries[key]
+	if found {
+		c.lru.MoveToFront(e)
+	}
+	c.mu.Unlock()
+	if !found {
+		atomic.AddUint64(&c.misses, 1)
+		return nil
+	}
+	atomic.AddUint64(&c.hits, 1)
+	return e.Value.(*decodeCacheEntry).m
+}
+
+// put caches m, which takes size bytes, for key, evicting the least recently
+// used matrices until the cache fits its limit.  A matrix larger than the
+// whole limit is not cached.
+func (c *decodeCache) put(key shardBitmap, m interface{}, size int) {
+	if size > c.limit {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, found := c.entries[key]; found {
+		// Another goroutine raced us to the same inversion.
+		c.lru.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(&decodeCacheEntry{key, m, size})
+	c.bytes += size
+	for c.bytes > c.limit {
+		oldest := c.lru.Back()
+		entry := oldest.Value.(*decodeCacheEntry)
+		c.lru.Remove(oldest)
+		delete(c.entries, entry.key)
+		c.bytes -= entry.size
+	}
+}
+
+func (c *decodeCache) stats() CacheStats {
+	c.mu.Lock()
+	entries, bytes := c.lru.Len(), c.bytes
+	c.mu.Unlock()
+	return CacheStats{
+		Hits:    atomic.LoadUint64(&c.hits),
+		Misses:  atomic.LoadUint64(&c.misses),
+		Entries: entries,
+		Bytes:   bytes,
+		Limit:   c.limit,
+	}
+}
@@ -0,0 +1,187 @@
+package galoisfield
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+func TestDecodeCache_eviction(t *testing.T) {
+	// Each 2×2 matrix takes 4 bytes, so two fit.
+	c := newDecodeCache(8)
+	keys := []shardBitmap{
+		newShardBitmap([]int{0, 1}),
+		newShardBitmap([]int{0, 2}),
+		newShardBitmap([]int{1, 255}),
+	}
+	m0, _ := Identity(2)
+	m1, _ := Identity(2)
+	m2, _ := Identity(2)
+	c.put(keys[0], m0, 4)
+	c.put(keys[1], m1, 4)
+	// Touching keys[0] makes keys[1] the least recently used.
+	if c.get(keys[0]) == nil {
+		t.Fatal("expected a hit for keys[0]")
+	}
+	c.put(keys[2], m2, 4)
+	if c.get(keys[1]) != nil {
+		t.Errorf("expected keys[1] to be evicted")
+	}
+	if c.get(keys[0]) == nil || c.get(keys[2]) == nil {
+		t.Errorf("expected keys[0] and keys[2] to remain")
+	}
+	expect := CacheStats{Hits: 3, Misses: 1, Entries: 2, Bytes: 8, Limit: 8}
+	if stats := c.stats(); stats != expect {
+		t.Errorf("expected %+v, got %+v", expect, stats)
+	}
+
+	// A bigger matrix evicts as many as it needs to, and one bigger than the
+	// whole cache is not kept at all.
+	m3, _ := Identity(2)
+	c.put(newShardBitmap([]int{3, 4}), m3, 8)
+	if stats := c.stats(); stats.Entries != 1 || stats.Bytes != 8 {
+		t.Errorf("expected one entry of 8 bytes, got %+v", stats)
+	}
+	c.put(newShardBitmap([]int{5, 6}), m3, 9)
+	if c.get(newShardBitmap([]int{5, 6})) != nil || c.stats().Bytes != 8 {
+		t.Errorf("expected an oversized matrix not to be cached, got %+v", c.stats())
+	}
+}
+
+func TestRaid6_decodeCache(t *testing.T) {
+	prng := rand.New(rand.NewSource(42))
+	const dataShards = 6
+	// Room for four 6×6 decode matrices.
+	cached, _ := Raid6NewWithCacheSize(dataShards, 2, 4*dataShards*dataShards)
+	uncached, _ := Raid6NewWithCacheSize(dataShards, 2, 0)
+	shards := make([][]byte, dataShards+2)
+	for i := range shards {
+		shards[i] = make([]byte, 100)
+	}
+	for _, shard := range shards[:dataShards] {
+		prng.Read(shard)
+	}
+	if err := cached.Encode(shards); err != nil {
+		t.Fatal(err)
+	}
+
+	// Reconstruct each pattern twice with the cache, so that the second
+	// try hits, and once without it.
+	var patterns int
+	for a := range shards {
+		for b := a + 1; b < len(shards); b++ {
+			patterns++
+			results := make([][][]byte, 3)
+			for i, enc := range []Encoder{cached, cached, uncached} {
+				damaged := make([][]byte, len(shards))
+				copy(damaged, shards)
+				damaged[a], damaged[b] = nil, nil
+				if err := enc.Reconstruct(damaged); err != nil {
+					t.Fatalf("lost %d,%d: %v", a, b, err)
+				}
+				results[i] = damaged
+			}
+			for i := range shards {
+				for _, result := range results {
+					if !equalBytes(result[i], shards[i]) {
+						t.Errorf("lost %d,%d: shard %d: cached %v, %v, uncached %v, expected %v",
+							a, b, i, results[0][i], results[1][i], results[2][i], shards[i])
+						break
+					}
+				}
+			}
+		}
+	}
+
+	stats := cached.CacheStats()
+	// Losing only parity needs no decode matrix.
+	expect := CacheStats{
+		Hits:    uint64(patterns - 1),
+		Misses:  uint64(patterns - 1),
+		Entries: 4,
+		Bytes:   4 * dataShards * dataShards,
+		Limit:   4 * dataShards * dataShards,
+	}
+	if stats != expect {
+		t.Errorf("expected %+v, got %+v", expect, stats)
+	}
+	if stats := uncached.CacheStats(); stats != (CacheStats{}) {
+		t.Errorf("expected zero stats with the cache disabled, got %+v", stats)
+	}
+	if enc, err := Raid6New(0, 2); enc != nil || err != ErrInvShardNum {
+		t.Errorf("expected a nil Encoder and ErrInvShardNum, got %#v, %v", enc, err)
+	}
+}
+
+func TestRaid6_decodeCache_concurrent(t *testing.T) {
+	const dataShards = 10
+	enc, _ := Raid6NewWithCacheSize(dataShards, 2, 8*dataShards*dataShards)
+	prng := rand.New(rand.NewSource(42))
+	shards := make([][]byte, dataShards+2)
+	for i := range shards {
+		shards[i] = make([]byte, 64)
+	}
+	for _, shard := range shards[:dataShards] {
+		prng.Read(shard)
+	}
+	if err := enc.Encode(shards); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			prng := rand.New(rand.NewSource(seed))
+			for i := 0; i < 200; i++ {
+				damaged := make([][]byte, len(shards))
+				copy(damaged, shards)
+				for _, j := range prng.Perm(len(shards))[:2] {
+					damaged[j] = nil
+				}
+				if err := enc.ReconstructData(damaged); err != nil {
+					t.Error(err)
+					return
+				}
+				for j := range shards[:dataShards] {
+					if !equalBytes(damaged[j], shards[j]) {
+						t.Errorf("shard %d: expected %v, got %v", j, shards[j], damaged[j])
+						return
+					}
+				}
+			}
+		}(int64(g))
+	}
+	wg.Wait()
+	if stats := enc.CacheStats(); stats.Entries > 8 || stats.Bytes > stats.Limit {
+		t.Errorf("cache exceeded its limit: %+v", stats)
+	}
+}
+
+func BenchmarkRaid6_Reconstruct_10x4K(b *testing.B) {
+	for _, cacheSize := range []int{0, DefaultDecodeCacheSize} {
+		name := "uncached"
+		if cacheSize > 0 {
+			name = "cached"
+		}
+		b.Run(name, func(b *testing.B) {
+			enc, _ := Raid6NewWithCacheSize(10, 2, cacheSize)
+			shards := make([][]byte, 12)
+			for i := range shards {
+				shards[i] = make([]byte, 4<<10)
+			}
+			enc.Encode(shards)
+			damaged := make([][]byte, len(shards))
+			b.SetBytes(10 * 4 << 10)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				copy(damaged, shards)
+				damaged[2], damaged[7] = damaged[2][:0], damaged[7][:0]
+				if err := enc.ReconstructData(damaged); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
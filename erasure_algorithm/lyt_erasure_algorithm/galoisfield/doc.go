@@ -24,6 +24,8 @@
 // 
 // Finite fields -- and `GF(2**8)` in particular -- get a tons of use in codes,
 // in both the "error-correcting code" and "cryptographic code" senses.
-// However, this implementation has NOT been hardened against timing attacks,
-// so it MUST NOT be used in cryptography.
+// However, the table-driven GF has NOT been hardened against timing attacks,
+// so it MUST NOT be used in cryptography.  Use GF.ConstantTime to obtain a
+// ConstantTimeGF, which computes the same Mul/Div/Inv/Exp results without
+// table lookups or branches on secret values.
 package galoisfield
@@ -0,0 +1,108 @@
+package galoisfield
+
+import (
+	"errors"
+)
+
+var (
+	ErrSubfieldDegree = errors.New("subfield degree must divide the field degree")
+)
+
+// Frobenius returns x**(2**i), i.e. the Frobenius automorphism applied to x i
+// times.  The automorphism has order k, so i is taken modulo k.
+func (gf *GF) Frobenius(x byte, i uint) byte {
+	if x == 0 {
+		return 0
+	}
+	e := uint(gf.log[x])
+	for j := uint(0); j < i%uint(gf.k); j++ {
+		e = (2 * e) % gf.m
+	}
+	return gf.exp[e]
+}
+
+// Trace returns the absolute trace x + x**2 + x**4 + ... + x**(2**(k-1)),
+// which is always 0 or 1.
+func (gf *GF) Trace(x byte) byte {
+	var sum byte
+	for i := uint(0); i < uint(gf.k); i++ {
+		sum ^= gf.Frobenius(x, i)
+	}
+	return sum
+}
+
+// Norm returns the norm of x relative to the subfield GF(2**d), i.e. the
+// product x * x**(2**d) * x**(2**(2d)) * ... of the k/d conjugates of x over
+// that subfield, which always lies in the subfield.  Norm(x, 1) is the
+// absolute norm, which is 1 for every nonzero x.  It panics with
+// ErrSubfieldDegree unless d divides k.
+func (gf *GF) Norm(x byte, d uint) byte {
+	if d == 0 || uint(gf.k)%d != 0 {
+		panic(ErrSubfieldDegree)
+	}
+	if x == 0 {
+		return 0
+	}
+	// The product is x**((2**k - 1) / (2**d - 1)).
+	e := uint(gf.log[x]) * (gf.m / (1<<d - 1))
+	return gf.exp[e%gf.m]
+}
+
+// Sqrt returns the unique y such that y*y == x.  Every element of GF(2**k)
+// has exactly one square root.
+func (gf *GF) Sqrt(x byte) byte {
+	if x == 0 {
+		return 0
+	}
+	// The group order m is odd, so exactly one of e and e+m is even.
+	e := uint(gf.log[x])
+	if e%2 != 0 {
+		e += gf.m
+	}
+	return gf.exp[e/2]
+}
+
+// Order returns the multiplicative order of x, i.e. the smallest n > 0 such
+// that x**n == 1.  By convention, Order(0) is 0.
+func (gf *GF) Order(x byte) uint {
+	if x == 0 {
+		return 0
+	}
+	return gf.m / gcd(uint(gf.log[x]), gf.m)
+}
+
+// MinimalPolynomial returns the monic polynomial of least degree with binary
+// coefficients that has x as a root.  It is always irreducible, and for a
+// generator x it is primitive.
+func (gf *GF) MinimalPolynomial(x byte) BinaryPoly {
+	// The roots are the distinct conjugates x, x**2, x**4, ...; multiply out
+	// the product of (X - conjugate).  Coefficients are little-endian.
+	poly := []byte{1}
+	conj := x
+	for {
+		next := make([]byte, len(poly)+1)
+		for i, k := range poly {
+			next[i+1] ^= k
+			next[i] ^= gf.Mul(k, conj)
+		}
+		poly = next
+		conj = gf.Mul(conj, conj)
+		if conj == x {
+			break
+		}
+	}
+	var p BinaryPoly
+	for i, k := range poly {
+		// The product is invariant under Frobenius, so every k is 0 or 1.
+		p |= BinaryPoly(k) << uint(i)
+	}
+	return p
+}
+
+// gcd returns the greatest common divisor of a and b.
+func gcd(a, b uint) uint {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
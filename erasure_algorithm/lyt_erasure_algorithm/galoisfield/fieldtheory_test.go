@@ -0,0 +1,156 @@
+package galoisfield
+
+import (
+	"testing"
+)
+
+func TestGF_Frobenius(t *testing.T) {
+	for _, wk := range wellknown[1:] {
+		gf := wk.field
+		for x := uint(0); x < gf.Size(); x++ {
+			expect := byte(x)
+			for i := uint(0); i <= 2*uint(gf.k); i++ {
+				if actual := gf.Frobenius(byte(x), i); actual != expect {
+					t.Errorf("%s: expected Frobenius(%d, %d)=%d, got %d", wk.name, x, i, expect, actual)
+				}
+				expect = gf.Mul(expect, expect)
+			}
+		}
+	}
+}
+
+func TestGF_Trace(t *testing.T) {
+	for _, wk := range wellknown[1:] {
+		gf := wk.field
+		ones := uint(0)
+		for x := uint(0); x < gf.Size(); x++ {
+			var expect byte
+			y := byte(x)
+			for i := byte(0); i < gf.k; i++ {
+				expect ^= y
+				y = gf.Mul(y, y)
+			}
+			actual := gf.Trace(byte(x))
+			if actual != expect {
+				t.Errorf("%s: expected Trace(%d)=%d, got %d", wk.name, x, expect, actual)
+			}
+			if actual > 1 {
+				t.Errorf("%s: expected Trace(%d) in {0,1}, got %d", wk.name, x, actual)
+			}
+			ones += uint(actual)
+		}
+		// The trace is a surjective linear map onto GF(2).
+		if ones != gf.Size()/2 {
+			t.Errorf("%s: expected %d elements with trace 1, got %d", wk.name, gf.Size()/2, ones)
+		}
+	}
+}
+
+func TestGF_Norm(t *testing.T) {
+	for _, wk := range wellknown[1:] {
+		gf := wk.field
+		for d := uint(1); d <= uint(gf.k); d++ {
+			if uint(gf.k)%d != 0 {
+				e := panicValue(func() {
+					gf.Norm(2, d)
+				})
+				if e != ErrSubfieldDegree {
+					t.Errorf("%s: Norm(2, %d): expected panic(ErrSubfieldDegree), got %v", wk.name, d, e)
+				}
+				continue
+			}
+			for x := uint(0); x < gf.Size(); x++ {
+				expect := byte(1)
+				if x == 0 {
+					expect = 0
+				}
+				for i := uint(0); i < uint(gf.k); i += d {
+					expect = gf.Mul(expect, gf.Frobenius(byte(x), i))
+				}
+				actual := gf.Norm(byte(x), d)
+				if actual != expect {
+					t.Errorf("%s: expected Norm(%d, %d)=%d, got %d", wk.name, x, d, expect, actual)
+				}
+				// The norm lies in GF(2**d), which Frobenius**d fixes.
+				if gf.Frobenius(actual, d) != actual {
+					t.Errorf("%s: Norm(%d, %d)=%d is not in GF(2**%[3]d)", wk.name, x, d, actual)
+				}
+			}
+		}
+		if e := panicValue(func() { gf.Norm(2, 0) }); e != ErrSubfieldDegree {
+			t.Errorf("%s: Norm(2, 0): expected panic(ErrSubfieldDegree), got %v", wk.name, e)
+		}
+	}
+}
+
+func TestGF_Sqrt(t *testing.T) {
+	for _, wk := range wellknown[1:] {
+		gf := wk.field
+		for x := uint(0); x < gf.Size(); x++ {
+			s := gf.Sqrt(byte(x))
+			if sq := gf.Mul(s, s); sq != byte(x) {
+				t.Errorf("%s: expected Sqrt(%d)**2=%[2]d, got Sqrt=%d, square=%d", wk.name, x, s, sq)
+			}
+		}
+	}
+}
+
+func TestGF_Order(t *testing.T) {
+	for _, wk := range wellknown[1:] {
+		gf := wk.field
+		if o := gf.Order(0); o != 0 {
+			t.Errorf("%s: expected Order(0)=0, got %d", wk.name, o)
+		}
+		for x := uint(1); x < gf.Size(); x++ {
+			var expect uint = 1
+			for y := byte(x); y != 1; y = gf.Mul(y, byte(x)) {
+				expect++
+			}
+			if actual := gf.Order(byte(x)); actual != expect {
+				t.Errorf("%s: expected Order(%d)=%d, got %d", wk.name, x, expect, actual)
+			}
+		}
+		if o := gf.Order(byte(gf.g)); o != gf.Size()-1 {
+			t.Errorf("%s: expected the generator to have order %d, got %d", wk.name, gf.Size()-1, o)
+		}
+	}
+}
+
+func TestGF_MinimalPolynomial(t *testing.T) {
+	for _, wk := range wellknown[1:] {
+		gf := wk.field
+		for x := uint(0); x < gf.Size(); x++ {
+			p := gf.MinimalPolynomial(byte(x))
+			if evalBinaryPoly(gf, p, byte(x)) != 0 {
+				t.Errorf("%s: MinimalPolynomial(%d)=%v does not vanish at %[2]d", wk.name, x, p)
+			}
+			if !p.IsIrreducible() {
+				t.Errorf("%s: MinimalPolynomial(%d)=%v is reducible", wk.name, x, p)
+			}
+			if gf.Order(byte(x)) == gf.m && !p.IsPrimitive() {
+				t.Errorf("%s: MinimalPolynomial(%d)=%v of a generator is not primitive", wk.name, x, p)
+			}
+			// No nonzero polynomial of smaller degree vanishes at x.
+			for q := BinaryPoly(1); q < 1<<uint(p.Degree()); q++ {
+				if evalBinaryPoly(gf, q, byte(x)) == 0 {
+					t.Errorf("%s: MinimalPolynomial(%d)=%v, but %v also vanishes", wk.name, x, p, q)
+					break
+				}
+			}
+		}
+		if gf.g == 2 {
+			if p := gf.MinimalPolynomial(2); p != BinaryPoly(gf.p) {
+				t.Errorf("%s: expected MinimalPolynomial(2)=%v, got %v", wk.name, BinaryPoly(gf.p), p)
+			}
+		}
+	}
+}
+
+// evalBinaryPoly evaluates a polynomial with binary coefficients at x.
+func evalBinaryPoly(gf *GF, p BinaryPoly, x byte) byte {
+	var sum byte
+	for i := p.Degree(); i >= 0; i-- {
+		sum = gf.Mul(sum, x) ^ byte((p>>uint(i))&1)
+	}
+	return sum
+}
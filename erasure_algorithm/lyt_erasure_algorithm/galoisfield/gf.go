@@ -3,7 +3,6 @@ package galoisfield
 import (
 	"errors"
 	"fmt"
-	"strings"
 	"sync"
 )
 
@@ -25,9 +24,10 @@ type params struct {
 // GF represents a particular permutation of GF(2**k) for some fixed k.
 type GF struct {
 	params
-	m   uint
-	log []byte
-	exp []byte
+	m      uint
+	log    []byte
+	exp    []byte
+	tables *fullTables
 }
 
 var (
@@ -38,29 +38,29 @@ var (
 // Some handy pre-chosen polynomial/generator combinations.
 var (
 	// GF(4) p=(x^2 + x + 1) g=2
-	Poly210_g2 = New(4, 0x7, 2)
+	Poly210_g2 = MustNew(4, 0x7, 2)
 
 	// GF(8) p=(x^3 + x + 1) g=2
-	Poly310_g2 = New(8, 0xb, 2)
+	Poly310_g2 = MustNew(8, 0xb, 2)
 
 	// GF(16) p=(x^4 + x + 1) g=2
-	Poly410_g2 = New(16, 0x13, 2)
+	Poly410_g2 = MustNew(16, 0x13, 2)
 
 	// GF(32) p=(x^5 + x^2 + 1) g=2
-	Poly520_g2 = New(32, 0x25, 2)
+	Poly520_g2 = MustNew(32, 0x25, 2)
 
 	// GF(64) p=(x^6 + x + 1) g=2
-	Poly610_g2 = New(64, 0x43, 2)
+	Poly610_g2 = MustNew(64, 0x43, 2)
 	// GF(64) p=(x^6 + x + 1) g=7
-	Poly610_g7 = New(64, 0x43, 7)
+	Poly610_g7 = MustNew(64, 0x43, 7)
 
 	// GF(128) p=(x^7 + x + 1) g=2
-	Poly710_g2 = New(128, 0x83, 2)
+	Poly710_g2 = MustNew(128, 0x83, 2)
 
 	// GF(256), p (x^8 + x^4 + x^3 + x + 1), g 3
-	Poly84310_g3 = New(256, 0x11b, 0x03)
+	Poly84310_g3 = MustNew(256, 0x11b, 0x03)
 	// GF(256), p (x^8 + x^4 + x^3 + x^2 + 1), g 2
-	Poly84320_g2 = New(256, 0x11d, 0x02)
+	Poly84320_g2 = MustNew(256, 0x11d, 0x02)
 
 	// Some arbitrarily-chosen permutations of GF(n).
 	DefaultGF4   = Poly210_g2
@@ -75,6 +75,8 @@ var (
 	Default = DefaultGF256
 )
 
+//go:generate go run ./internal/gentables -o tables_gen.go
+
 type wki struct {
 	field *GF
 	name  string
@@ -93,12 +95,14 @@ var wellknown = []wki{
 	wki{Poly84320_g2, "Poly84320_g2"},
 }
 
-// New takes n (a power of 2), p (a polynomial), and g (a generator), then uses
-// them to construct an instance of GF(n).  This comes complete with
+// NewField takes n (a power of 2), p (a polynomial), and g (a generator), then
+// uses them to construct an instance of GF(n).  This comes complete with
 // precomputed g**x and log_g(x) tables, so that all operations take O(1) time.
 //
 // If n isn't a supported power of 2, if p is reducible or of the wrong degree,
-// or if g isn't actually a generator for the field, this function will panic.
+// or if g isn't actually a generator for the field, this function returns
+// ErrFieldSize, ErrReduciblePoly, ErrPolyOutOfRange or ErrNotGenerator
+// respectively.
 //
 // In the following, let k := log_2(n).
 //
@@ -115,26 +119,29 @@ var wellknown = []wki{
 //
 //	g**0, g**1, g**2, ... g**(n-1)
 //
-// must be a complete list of all elements in the field.  The field is small
-// enough that the easiest way to discover generators is trial-and-error.
+// must be a complete list of all elements in the field.  IrreduciblePolynomials
+// lists every valid p for a given n, and Generators lists every valid g for a
+// given n and p.
 //
 // The "p" and "g" arguments both have no effect on Add.
 // The "g" argument additionally has no effect on (the output of) Mul/Div/Inv.
 // Both arguments affect Exp/Log.
-func New(n, p uint, g byte) *GF {
+func NewField(n, p uint, g byte) (*GF, error) {
 	k, ok := log2table[n]
 	if !ok {
-		panic(ErrFieldSize)
+		return nil, ErrFieldSize
 	}
 	m := n - 1
 	if p < n || p >= 2*n {
-		panic(ErrPolyOutOfRange)
+		return nil, ErrPolyOutOfRange
 	}
-	if g == 0 || g == 1 {
-		panic(ErrNotGenerator)
+	// g must be an element of the field; a larger g would alias a smaller
+	// generator under a distinct singleton, or yield a broken field.
+	if g == 0 || g == 1 || uint(g) >= n {
+		return nil, ErrNotGenerator
 	}
-	if isReducible(p) {
-		panic(ErrReduciblePoly)
+	if !BinaryPoly(p).IsIrreducible() {
+		return nil, ErrReduciblePoly
 	}
 	params := params{
 		p: uint16(p),
@@ -146,27 +153,21 @@ func New(n, p uint, g byte) *GF {
 	singleton, found := global[params]
 	mu.Unlock()
 	if found {
-		return singleton
+		return singleton, nil
 	}
 
 	gf := &GF{
 		params: params,
 		m:      m,
-		log:    make([]byte, n),
-		exp:    make([]byte, 2*n-2),
+		tables: new(fullTables),
 	}
-
-	// Use the generator to compute the exp/log tables.  We perform the
-	// usual trick of doubling the exp table to simplify Mul.
-	var x byte = 1
-	for i := uint(0); i < m; i++ {
-		if x == 1 && i != 0 {
-			panic(ErrNotGenerator)
+	gf.log, gf.exp, gf.tables.mul, gf.tables.inv = generatedTables(params)
+	if gf.log == nil {
+		var err error
+		gf.log, gf.exp, err = buildTables(params)
+		if err != nil {
+			return nil, err
 		}
-		gf.exp[i] = x
-		gf.exp[i+m] = x
-		gf.log[x] = byte(i)
-		x = mulSlow(x, g, byte(p), k)
 	}
 
 	mu.Lock()
@@ -176,7 +177,102 @@ func New(n, p uint, g byte) *GF {
 		global[params] = singleton
 	}
 	mu.Unlock()
-	return singleton
+	return singleton, nil
+}
+
+// buildTables computes the log and exp tables for the given parameters.  We
+// perform the usual trick of doubling the exp table to simplify Mul.
+func buildTables(params params) (log, exp []byte, err error) {
+	n := uint(1) << params.k
+	m := n - 1
+	log = make([]byte, n)
+	exp = make([]byte, 2*m)
+	var x byte = 1
+	for i := uint(0); i < m; i++ {
+		if x == 1 && i != 0 {
+			return nil, nil, ErrNotGenerator
+		}
+		exp[i] = x
+		exp[i+m] = x
+		log[x] = byte(i)
+		x = mulSlow(x, params.g, byte(params.p), params.k)
+	}
+	return log, exp, nil
+}
+
+// MustNew is like NewField, but panics instead of returning an error.  It is
+// intended for package-level variables and other values known to be valid.
+func MustNew(n, p uint, g byte) *GF {
+	gf, err := NewField(n, p, g)
+	if err != nil {
+		panic(err)
+	}
+	return gf
+}
+
+// New is equivalent to MustNew.  New code that builds fields from untrusted
+// input should use NewField instead.
+func New(n, p uint, g byte) *GF {
+	return MustNew(n, p, g)
+}
+
+// IrreduciblePolynomials returns, in increasing order, every polynomial p
+// that NewField accepts for a field of size n, i.e. every irreducible
+// polynomial of degree log_2(n).
+func IrreduciblePolynomials(n uint) ([]uint, error) {
+	return polynomialsOfSize(n, BinaryPoly.IsIrreducible)
+}
+
+// PrimitivePolynomials returns, in increasing order, the primitive
+// polynomials of degree log_2(n): those irreducible polynomials for which 2
+// is among the Generators, as reported by BinaryPoly.IsPrimitive.
+func PrimitivePolynomials(n uint) ([]uint, error) {
+	return polynomialsOfSize(n, BinaryPoly.IsPrimitive)
+}
+
+func polynomialsOfSize(n uint, accept func(BinaryPoly) bool) ([]uint, error) {
+	if _, ok := log2table[n]; !ok {
+		return nil, ErrFieldSize
+	}
+	var list []uint
+	for p := n; p < 2*n; p++ {
+		if accept(BinaryPoly(p)) {
+			list = append(list, p)
+		}
+	}
+	return list, nil
+}
+
+// Generators returns, in increasing order, every g for which NewField(n, p, g)
+// succeeds.  It returns the same errors as NewField if n or p is invalid.
+func Generators(n, p uint) ([]byte, error) {
+	k, ok := log2table[n]
+	if !ok {
+		return nil, ErrFieldSize
+	}
+	if p < n || p >= 2*n {
+		return nil, ErrPolyOutOfRange
+	}
+	if !BinaryPoly(p).IsIrreducible() {
+		return nil, ErrReduciblePoly
+	}
+	var list []byte
+	for g := uint(2); g < n; g++ {
+		// g is a generator iff its multiplicative order is n-1.
+		var x byte = 1
+		var order uint
+		for {
+			x = mulSlow(x, byte(g), byte(p), k)
+			order++
+			if x == 1 {
+				break
+			}
+		}
+		if order == n-1 {
+			list = append(list, byte(g))
+		}
+	}
+	return list, nil
 }
 
 // Size returns the order of the Galois field, i.e. the number of elements.
@@ -221,8 +317,11 @@ func (a *GF) Less(b *GF) bool {
 
 // GoString returns a Go-syntax representation of this GF.
 func (gf *GF) GoString() string {
-	for _, wk := range wellknown {
-		if gf == wk.field {
+	if gf == nil {
+		return wellknown[0].name
+	}
+	for _, wk := range wellknown[1:] {
+		if gf.params == wk.field.params {
 			return wk.name
 		}
 	}
@@ -234,22 +333,7 @@ func (gf *GF) String() string {
 	if gf == nil {
 		return "<nil>"
 	}
-	var poly []string
-	for i := 8; i >= 0; i-- {
-		if (gf.p & (1 << uint(i))) != 0 {
-			var mono string
-			if i == 0 {
-				mono = "1"
-			} else if i == 1 {
-				mono = "b"
-			} else {
-				mono = fmt.Sprintf("b^%d", i)
-			}
-			poly = append(poly, mono)
-		}
-	}
-	polystr := strings.Join(poly, "+")
-	return fmt.Sprintf("GF(%d;%s;%d)", 1<<gf.k, polystr, gf.g)
+	return fmt.Sprintf("GF(%d;%s;%d)", 1<<gf.k, BinaryPoly(gf.p), gf.g)
 }
 
 // Add returns x+y == x-y == x^y in GF(2**k).
@@ -295,6 +379,29 @@ func (gf *GF) Log(x byte) byte {
 	return gf.log[x]
 }
 
+// Pow returns x**e in GF(2**k) in O(1) time.  The exponent may be negative,
+// in which case the result is (1/x)**(-e), and 0**0 is 1.  Pow panics with
+// ErrDivByZero if x is 0 and e is negative.
+func (gf *GF) Pow(x byte, e int) byte {
+	if x == 0 {
+		if e < 0 {
+			panic(ErrDivByZero)
+		}
+		if e == 0 {
+			return 1
+		}
+		return 0
+	}
+	// Reduce e modulo the order of the multiplicative group first, so that
+	// the product below cannot overflow.
+	m := int(gf.m)
+	e %= m
+	if e < 0 {
+		e += m
+	}
+	return gf.exp[(uint(gf.log[x])*uint(e))%gf.m]
+}
+
 // mulSlow returns x*y mod poly.
 func mulSlow(x, y, poly, k byte) byte {
 	var hibit byte = (1 << (k - 1))
@@ -313,39 +420,4 @@ func mulSlow(x, y, poly, k byte) byte {
 	return p
 }
 
-// isReducible returns true iff it can find a smaller polynomial that evenly
-// divides the given polynomial.
-func isReducible(p uint) bool {
-	var n uint = 1 << ((degree(p) / 2) + 1)
-	for divisor := uint(2); divisor < n; divisor++ {
-		if polyDiv(p, divisor) == 0 {
-			return true
-		}
-	}
-	return false
-}
-
-// polyDiv divides two polynomials and returns the remainder.
-func polyDiv(dividend, divisor uint) uint {
-	for m, n := degree(dividend), degree(divisor); m >= n; m-- {
-		if (dividend & (1 << (m - 1))) != 0 {
-			dividend ^= divisor << (m - n)
-		}
-	}
-	return dividend
-}
-
-// degree returns the degree of the polynomial.  In this representation, the
-// degree of a polynomial is:
-//	[p == 0] 0
-//	[p >  0] (k+1) such that (1<<k) is the highest 1 bit
-func degree(p uint) uint {
-	var d uint
-	for p > 0 {
-		d++
-		p >>= 1
-	}
-	return d
-}
-
 var log2table = map[uint]byte{2: 1, 4: 2, 8: 3, 16: 4, 32: 5, 64: 6, 128: 7, 256: 8}
@@ -0,0 +1,218 @@
+package galoisfield
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var (
+	ErrOddLength = errors.New("slice length is not a multiple of 2 bytes")
+)
+
+type params16 struct {
+	p uint32
+	g uint16
+}
+
+// GF65536 represents a particular permutation of GF(2**16).  It offers the
+// same operations as GF, but on uint16 elements, so that a stripe may hold up
+// to 65535 shards instead of 255.  (DefaultGF16, by contrast, is the 16-element
+// field GF(2**4).)
+type GF65536 struct {
+	params16
+	log []uint16
+	exp []uint16
+}
+
+const gf16Order = 1<<16 - 1
+
+var (
+	mu16     sync.Mutex
+	global16 map[params16]*GF65536 = make(map[params16]*GF65536)
+)
+
+var (
+	// GF(65536), p (x^16 + x^12 + x^3 + x + 1), g 2
+	Poly1612310_g2 = NewGF65536(0x1100b, 2)
+
+	// Some arbitrarily-chosen permutation of GF(65536).
+	DefaultGF65536 = Poly1612310_g2
+)
+
+// NewGF65536 takes p (a polynomial of degree 16) and g (a generator), then
+// uses them to construct an instance of GF(65536).  It follows the same rules as
+// New: p must be irreducible with bit 16 set and no higher bits, and g must
+// generate the whole multiplicative group.  Otherwise this function panics.
+func NewGF65536(p uint, g uint16) *GF65536 {
+	if p < 1<<16 || p >= 1<<17 {
+		panic(ErrPolyOutOfRange)
+	}
+	if g == 0 || g == 1 {
+		panic(ErrNotGenerator)
+	}
+	if !BinaryPoly(p).IsIrreducible() {
+		panic(ErrReduciblePoly)
+	}
+	params := params16{p: uint32(p), g: g}
+
+	mu16.Lock()
+	singleton, found := global16[params]
+	mu16.Unlock()
+	if found {
+		return singleton
+	}
+
+	gf := &GF65536{
+		params16: params,
+		log:      make([]uint16, 1<<16),
+		exp:      make([]uint16, 2*gf16Order),
+	}
+	var x uint16 = 1
+	for i := uint(0); i < gf16Order; i++ {
+		if x == 1 && i != 0 {
+			panic(ErrNotGenerator)
+		}
+		gf.exp[i] = x
+		gf.exp[i+gf16Order] = x
+		gf.log[x] = uint16(i)
+		x = mulSlow16(x, g, uint32(p))
+	}
+
+	mu16.Lock()
+	singleton, found = global16[params]
+	if !found {
+		singleton = gf
+		global16[params] = singleton
+	}
+	mu16.Unlock()
+	return singleton
+}
+
+// Size returns the order of the Galois field, i.e. the number of elements.
+func (gf *GF65536) Size() uint { return 1 << 16 }
+
+// Polynomial returns the polynomial used to generate the Galois field.
+func (gf *GF65536) Polynomial() uint { return uint(gf.p) }
+
+// Generator returns the exponent base used to generate the Galois field.
+func (gf *GF65536) Generator() uint { return uint(gf.g) }
+
+// GoString returns a Go-syntax representation of this GF65536.
+func (gf *GF65536) GoString() string {
+	if gf == DefaultGF65536 {
+		return "Poly1612310_g2"
+	}
+	return fmt.Sprintf("NewGF65536(%#x, %d)", gf.p, gf.g)
+}
+
+// String returns a human-readable representation of this GF65536.
+func (gf *GF65536) String() string {
+	if gf == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("GF(65536;%s;%d)", BinaryPoly(gf.p), gf.g)
+}
+
+// Add returns x+y == x-y == x^y in GF(2**16).
+func (_ *GF65536) Add(x, y uint16) uint16 { return x ^ y }
+
+// Mul returns x*y in GF(2**16).
+func (gf *GF65536) Mul(x, y uint16) uint16 {
+	if x == 0 || y == 0 {
+		return 0
+	}
+	return gf.exp[uint(gf.log[x])+uint(gf.log[y])]
+}
+
+// Div returns x/y in GF(2**16).
+func (gf *GF65536) Div(x, y uint16) uint16 {
+	if x == 0 || y == 0 {
+		if y == 0 {
+			panic(ErrDivByZero)
+		}
+		return 0
+	}
+	return gf.exp[gf16Order+uint(gf.log[x])-uint(gf.log[y])]
+}
+
+// Inv returns 1/x in GF(2**16).
+func (gf *GF65536) Inv(x uint16) uint16 {
+	if x == 0 {
+		panic(ErrDivByZero)
+	}
+	return gf.exp[gf16Order-uint(gf.log[x])]
+}
+
+// Exp returns g**x in GF(2**16).
+func (gf *GF65536) Exp(x uint16) uint16 {
+	return gf.exp[uint(x)%gf16Order]
+}
+
+// Log returns log_g(x) in GF(2**16).
+func (gf *GF65536) Log(x uint16) uint16 {
+	if x == 0 {
+		panic(ErrLogZero)
+	}
+	return gf.log[x]
+}
+
+// MulSlice treats in and out as little-endian uint16 elements and sets
+// out[i] = c*in[i] for every element of in.
+//
+// len(in) must be even and out must be at least as long as in, or this
+// function will panic.
+func (gf *GF65536) MulSlice(c uint16, in, out []byte) {
+	gf.mulSlice(c, in, out, false)
+}
+
+// MulAddSlice treats in and out as little-endian uint16 elements and sets
+// out[i] = out[i] + c*in[i] for every element of in.
+//
+// len(in) must be even and out must be at least as long as in, or this
+// function will panic.
+func (gf *GF65536) MulAddSlice(c uint16, in, out []byte) {
+	gf.mulSlice(c, in, out, true)
+}
+
+func (gf *GF65536) mulSlice(c uint16, in, out []byte, add bool) {
+	if len(in)%2 != 0 {
+		panic(ErrOddLength)
+	}
+	if len(out) < len(in) {
+		panic(ErrSliceLength)
+	}
+	// One table per nibble of the input element.
+	var tables [4][16]uint16
+	for n := range tables {
+		for i := range tables[n] {
+			tables[n][i] = gf.Mul(c, uint16(i)<<(4*uint(n)))
+		}
+	}
+	for i := 0; i < len(in); i += 2 {
+		x := binary.LittleEndian.Uint16(in[i:])
+		y := tables[0][x&15] ^ tables[1][(x>>4)&15] ^ tables[2][(x>>8)&15] ^ tables[3][x>>12]
+		if add {
+			y ^= binary.LittleEndian.Uint16(out[i:])
+		}
+		binary.LittleEndian.PutUint16(out[i:], y)
+	}
+}
+
+// mulSlow16 returns x*y mod poly for a degree-16 poly.
+func mulSlow16(x, y uint16, poly uint32) uint16 {
+	var p uint16 = 0
+	for i := 0; i < 16; i++ {
+		if (y & 1) != 0 {
+			p ^= x
+		}
+		wasset := (x & 0x8000) != 0
+		x <<= 1
+		y >>= 1
+		if wasset {
+			x ^= uint16(poly)
+		}
+	}
+	return p
+}
@@ -0,0 +1,258 @@
+package galoisfield
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestNewGF65536(t *testing.T) {
+	gf := Poly1612310_g2
+	for i := uint(0); i < gf16Order; i++ {
+		x := gf.exp[i]
+		if y := gf.log[x]; uint(y) != i {
+			t.Fatalf("expected log[exp[i]]=i, got i=%d exp[.]=%d log[.]=%d", i, x, y)
+		}
+		if x2 := gf.exp[i+gf16Order]; x2 != x {
+			t.Fatalf("expected exp[i+65535]=exp[i], got i=%d %d != %d", i, x2, x)
+		}
+	}
+	if a, b := NewGF65536(0x1100b, 2), NewGF65536(0x1100b, 2); a != b {
+		t.Errorf("expected singleton, got multiple instances of %#v", a)
+	}
+	if s := gf.String(); s != "GF(65536;b^16+b^12+b^3+b+1;2)" {
+		t.Errorf("expected %q, got %q", "GF(65536;b^16+b^12+b^3+b+1;2)", s)
+	}
+	if s := gf.GoString(); s != "Poly1612310_g2" {
+		t.Errorf("expected %q, got %q", "Poly1612310_g2", s)
+	}
+}
+
+func TestNewGF65536_bad_params(t *testing.T) {
+	type testrow struct {
+		p      uint
+		g      uint16
+		expect error
+	}
+	for _, row := range []testrow{
+		testrow{0xffff, 2, ErrPolyOutOfRange},
+		testrow{0x20000, 2, ErrPolyOutOfRange},
+		testrow{0x10000, 2, ErrReduciblePoly},
+		testrow{0x1100b, 1, ErrNotGenerator},
+		testrow{0x1100b, 0, ErrNotGenerator},
+	} {
+		e := panicValue(func() {
+			NewGF65536(row.p, row.g)
+		})
+		if e != row.expect {
+			t.Errorf("NewGF65536(%#x, %d): expected panic(%v), got %v", row.p, row.g, row.expect, e)
+		}
+	}
+}
+
+func TestGF65536_Add(t *testing.T) {
+	var prng = rand.New(rand.NewSource(42))
+	gf := DefaultGF65536
+	for i := 0; i < 1024; i++ {
+		a := uint16(prng.Intn(1 << 16))
+		b := uint16(prng.Intn(1 << 16))
+		c := uint16(prng.Intn(1 << 16))
+		if aa := gf.Add(a, a); aa != 0 {
+			t.Errorf("[%5d] expected a+a=0, got %d", a, aa)
+		}
+		if az := gf.Add(a, 0); az != a {
+			t.Errorf("[%5d] expected a+0=a, got %d", a, az)
+		}
+		if ab, ba := gf.Add(a, b), gf.Add(b, a); ab != ba {
+			t.Errorf("[%5d,%5d] expected a+b=b+a, got %d != %d", a, b, ab, ba)
+		}
+		if x, y := gf.Add(a, gf.Add(b, c)), gf.Add(gf.Add(a, b), c); x != y {
+			t.Errorf("[%5d,%5d,%5d] expected a+(b+c)=(a+b)+c, got %d != %d", a, b, c, x, y)
+		}
+	}
+}
+
+func TestGF65536_Mul(t *testing.T) {
+	var prng = rand.New(rand.NewSource(42))
+	gf := DefaultGF65536
+	for i := 0; i < 1024; i++ {
+		a := uint16(prng.Intn(1 << 16))
+		b := uint16(prng.Intn(1 << 16))
+		c := uint16(prng.Intn(1 << 16))
+		if az := gf.Mul(a, 0); az != 0 {
+			t.Errorf("[%5d] expected a*0=0, got %d", a, az)
+		}
+		if ao := gf.Mul(a, 1); ao != a {
+			t.Errorf("[%5d] expected a*1=a, got %d", a, ao)
+		}
+		if ab, ba := gf.Mul(a, b), gf.Mul(b, a); ab != ba {
+			t.Errorf("[%5d,%5d] expected a*b=b*a, got %d != %d", a, b, ab, ba)
+		}
+		if ab, slow := gf.Mul(a, b), mulSlow16(a, b, gf.p); ab != slow {
+			t.Errorf("[%5d,%5d] expected a*b=%d, got %d", a, b, slow, ab)
+		}
+		if x, y := gf.Mul(a, gf.Mul(b, c)), gf.Mul(gf.Mul(a, b), c); x != y {
+			t.Errorf("[%5d,%5d,%5d] expected a*(b*c)=(a*b)*c, got %d != %d", a, b, c, x, y)
+		}
+		if x, y := gf.Mul(a, gf.Add(b, c)), gf.Add(gf.Mul(a, b), gf.Mul(a, c)); x != y {
+			t.Errorf("[%5d,%5d,%5d] expected a*(b+c)=a*b+a*c, got %d != %d", a, b, c, x, y)
+		}
+	}
+}
+
+func TestGF65536_Div(t *testing.T) {
+	gf := DefaultGF65536
+	for x := uint(1); x < gf.Size(); x++ {
+		a := uint16(x)
+		inv := gf.Inv(a)
+		if one := gf.Mul(a, inv); one != 1 {
+			t.Fatalf("[%5d] expected a*(1/a)=1, got %d", a, one)
+		}
+		if d := gf.Div(1, a); d != inv {
+			t.Fatalf("[%5d] expected 1/a=%d, got %d", a, inv, d)
+		}
+		b := uint16(x * 7919)
+		if d := gf.Div(gf.Mul(a, b), a); d != b {
+			t.Fatalf("[%5d,%5d] expected (a*b)/a=b, got %d", a, b, d)
+		}
+		if e := gf.Exp(gf.Log(a)); e != a {
+			t.Fatalf("[%5d] expected exp(log(a))=a, got %d", a, e)
+		}
+	}
+	e := panicValue(func() {
+		gf.Div(1, 0)
+	})
+	if e != ErrDivByZero {
+		t.Errorf("expected panic(ErrDivByZero), got %v", e)
+	}
+	e = panicValue(func() {
+		gf.Log(0)
+	})
+	if e != ErrLogZero {
+		t.Errorf("expected panic(ErrLogZero), got %v", e)
+	}
+}
+
+func TestGF65536_MulSlice(t *testing.T) {
+	var prng = rand.New(rand.NewSource(42))
+	gf := DefaultGF65536
+	in := make([]byte, 38)
+	prng.Read(in)
+	for i := 0; i < 64; i++ {
+		c := uint16(prng.Intn(1 << 16))
+		out := make([]byte, len(in))
+		prng.Read(out)
+		orig := append([]byte(nil), out...)
+		gf.MulAddSlice(c, in, out)
+		for j := 0; j < len(in); j += 2 {
+			x := uint16(in[j]) | uint16(in[j+1])<<8
+			y := uint16(orig[j]) | uint16(orig[j+1])<<8
+			expect := gf.Add(y, gf.Mul(c, x))
+			if actual := uint16(out[j]) | uint16(out[j+1])<<8; actual != expect {
+				t.Errorf("MulAddSlice(%d): [%d] expected %d, got %d", c, j/2, expect, actual)
+			}
+		}
+		gf.MulSlice(c, in, out)
+		for j := 0; j < len(in); j += 2 {
+			x := uint16(in[j]) | uint16(in[j+1])<<8
+			expect := gf.Mul(c, x)
+			if actual := uint16(out[j]) | uint16(out[j+1])<<8; actual != expect {
+				t.Errorf("MulSlice(%d): [%d] expected %d, got %d", c, j/2, expect, actual)
+			}
+		}
+	}
+	e := panicValue(func() {
+		gf.MulSlice(3, make([]byte, 3), make([]byte, 3))
+	})
+	if e != ErrOddLength {
+		t.Errorf("expected panic(ErrOddLength), got %v", e)
+	}
+}
+
+func TestGF65536_Raid6EncoderMatrix(t *testing.T) {
+	var prng = rand.New(rand.NewSource(42))
+	gf := DefaultGF65536
+	const dataShards = 300
+	m, err := gf.Raid6EncoderMatrix(dataShards+2, dataShards)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := NewMatrix65536(dataShards, 4)
+	for _, row := range data {
+		for c := range row {
+			row[c] = uint16(prng.Intn(1 << 16))
+		}
+	}
+	encoded, err := gf.MatrixMultiply(m, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Lose shards 7 and 123, then decode from the rest.
+	var sub, subEncoded Matrix65536
+	for r := range m {
+		if r == 7 || r == 123 {
+			continue
+		}
+		sub = append(sub, m[r])
+		subEncoded = append(subEncoded, encoded[r])
+	}
+	inv, err := gf.MatrixInvert(sub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := gf.MatrixMultiply(inv, subEncoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for r := range data {
+		for c := range data[r] {
+			if decoded[r][c] != data[r][c] {
+				t.Errorf("[%d][%d] expected %d, got %d", r, c, data[r][c], decoded[r][c])
+			}
+		}
+	}
+}
+
+func TestGF65536_Raid6EncoderMatrix_size(t *testing.T) {
+	gf := DefaultGF65536
+	type testrow struct {
+		rows, cols int
+		expect     error
+	}
+	for _, row := range []testrow{
+		testrow{2, 0, ErrInvalidColSize},
+		testrow{65536, 65534, ErrInvalidColSize},
+		testrow{1 << 17, 1 << 16, ErrInvalidColSize},
+		testrow{4, 3, ErrInvalidRowSize},
+	} {
+		if _, err := gf.Raid6EncoderMatrix(row.rows, row.cols); err != row.expect {
+			t.Errorf("Raid6EncoderMatrix(%d, %d): expected %v, got %v", row.rows, row.cols, row.expect, err)
+		}
+	}
+}
+
+func TestGF65536_MatrixInvert_errors(t *testing.T) {
+	gf := DefaultGF65536
+	for _, m := range []Matrix65536{nil, {}, {{}}, {{1, 2}, {3}}} {
+		if _, err := gf.MatrixInvert(m); err == nil {
+			t.Errorf("MatrixInvert(%v): expected an error", m)
+		}
+	}
+	wide, _ := NewMatrix65536(2, 3)
+	if _, err := gf.MatrixInvert(wide); err != ErrNotSquare {
+		t.Errorf("expected ErrNotSquare, got %v", err)
+	}
+	singular, _ := Matrix65536FromRows([][]uint16{{1, 2}, {2, 4}})
+	if _, err := gf.MatrixInvert(singular); err != ErrSingular {
+		t.Errorf("expected ErrSingular, got %v", err)
+	}
+}
+
+func BenchmarkGF65536_Mul(b *testing.B) {
+	gf := DefaultGF65536
+	var x uint16 = 1
+	var y uint16 = 3
+	for i := 0; i < b.N; i++ {
+		_ = gf.Mul(x, y)
+	}
+}
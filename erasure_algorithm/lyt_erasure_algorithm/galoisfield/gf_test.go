@@ -1,6 +1,7 @@
 package galoisfield
 
 import (
+	"math"
 	"math/rand"
 	"testing"
 )
@@ -145,6 +146,174 @@ func TestNew_bad_generator(t *testing.T) {
 	}
 }
 
+func TestNewField(t *testing.T) {
+	type testrow struct {
+		n, p   uint
+		g      byte
+		expect error
+	}
+	for _, row := range []testrow{
+		testrow{17, 0, 0, ErrFieldSize},
+		testrow{16, 15, 0, ErrPolyOutOfRange},
+		testrow{16, 32, 0, ErrPolyOutOfRange},
+		testrow{64, 0x42, 0x2, ErrReduciblePoly},
+		testrow{64, 0x43, 0x1, ErrNotGenerator},
+		testrow{64, 0x43, 0x3, ErrNotGenerator},
+		testrow{256, 0x11b, 0x2, ErrNotGenerator},
+		testrow{16, 0x13, 16, ErrNotGenerator},
+		testrow{16, 0x13, 18, ErrNotGenerator},
+	} {
+		gf, err := NewField(row.n, row.p, row.g)
+		if err != row.expect {
+			t.Errorf("NewField(%d, %#x, %d): expected %v, got %v", row.n, row.p, row.g, row.expect, err)
+		}
+		if gf != nil {
+			t.Errorf("NewField(%d, %#x, %d): expected nil field, got %#v", row.n, row.p, row.g, gf)
+		}
+		e := panicValue(func() {
+			MustNew(row.n, row.p, row.g)
+		})
+		if e != row.expect {
+			t.Errorf("MustNew(%d, %#x, %d): expected panic(%v), got %v", row.n, row.p, row.g, row.expect, e)
+		}
+	}
+
+	gf, err := NewField(256, 0x11d, 2)
+	if err != nil {
+		t.Fatalf("NewField(256, 0x11d, 2): unexpected error %v", err)
+	}
+	if gf != Poly84320_g2 {
+		t.Errorf("expected singleton %#v, got %#v", Poly84320_g2, gf)
+	}
+}
+
+func TestIrreduciblePolynomials(t *testing.T) {
+	type testrow struct {
+		n          uint
+		polys      int
+		generators int
+	}
+	// The number of irreducible binary polynomials of degree k, and Euler's
+	// totient of 2**k-1.
+	for _, row := range []testrow{
+		testrow{4, 1, 2},
+		testrow{8, 2, 6},
+		testrow{16, 3, 8},
+		testrow{32, 6, 30},
+		testrow{64, 9, 36},
+		testrow{128, 18, 126},
+		testrow{256, 30, 128},
+	} {
+		polys, err := IrreduciblePolynomials(row.n)
+		if err != nil {
+			t.Fatalf("IrreduciblePolynomials(%d): unexpected error %v", row.n, err)
+		}
+		if len(polys) != row.polys {
+			t.Errorf("IrreduciblePolynomials(%d): expected %d polynomials, got %d", row.n, row.polys, len(polys))
+		}
+		for _, p := range polys {
+			generators, err := Generators(row.n, p)
+			if err != nil {
+				t.Fatalf("Generators(%d, %#x): unexpected error %v", row.n, p, err)
+			}
+			if len(generators) != row.generators {
+				t.Errorf("Generators(%d, %#x): expected %d generators, got %d", row.n, p, row.generators, len(generators))
+			}
+			isGenerator := make(map[byte]bool)
+			for _, g := range generators {
+				isGenerator[g] = true
+			}
+			for g := uint(0); g < row.n; g++ {
+				gf, err := NewField(row.n, p, byte(g))
+				if (err == nil) != isGenerator[byte(g)] {
+					t.Errorf("NewField(%d, %#x, %d): expected generator=%v, got error %v",
+						row.n, p, g, isGenerator[byte(g)], err)
+				}
+				if err == nil {
+					checkRaid6Codec(t, gf)
+				}
+			}
+		}
+	}
+
+	if _, err := IrreduciblePolynomials(17); err != ErrFieldSize {
+		t.Errorf("IrreduciblePolynomials(17): expected ErrFieldSize, got %v", err)
+	}
+	if _, err := Generators(64, 0x42); err != ErrReduciblePoly {
+		t.Errorf("Generators(64, 0x42): expected ErrReduciblePoly, got %v", err)
+	}
+	if _, err := Generators(16, 32); err != ErrPolyOutOfRange {
+		t.Errorf("Generators(16, 32): expected ErrPolyOutOfRange, got %v", err)
+	}
+}
+
+func TestPrimitivePolynomials(t *testing.T) {
+	// The number of primitive binary polynomials of degree k is Euler's
+	// totient of 2**k-1, divided by k.
+	for n, expect := range map[uint]int{4: 1, 8: 2, 16: 2, 32: 6, 64: 6, 128: 18, 256: 16} {
+		polys, err := PrimitivePolynomials(n)
+		if err != nil {
+			t.Fatalf("PrimitivePolynomials(%d): unexpected error %v", n, err)
+		}
+		if len(polys) != expect {
+			t.Errorf("PrimitivePolynomials(%d): expected %d polynomials, got %d", n, expect, len(polys))
+		}
+		for _, p := range polys {
+			if _, err := NewField(n, p, 2); err != nil {
+				t.Errorf("NewField(%d, %#x, 2): expected 2 to generate, got error %v", n, p, err)
+			}
+		}
+	}
+	polys, _ := PrimitivePolynomials(256)
+	for _, p := range polys {
+		if p == 0x11b {
+			t.Errorf("PrimitivePolynomials(256): 0x11b is irreducible but not primitive")
+		}
+	}
+	if _, err := PrimitivePolynomials(17); err != ErrFieldSize {
+		t.Errorf("PrimitivePolynomials(17): expected ErrFieldSize, got %v", err)
+	}
+}
+
+// checkRaid6Codec encodes a few data rows with the RAID6 matrix of gf, then
+// checks that every pattern of two lost rows decodes.
+func checkRaid6Codec(t *testing.T, gf *GF) {
+	dataShards := 4
+	if int(gf.Size())-2 < dataShards {
+		dataShards = int(gf.Size()) - 2
+	}
+	m, _ := gf.Raid6EncoderMatrix(dataShards+2, dataShards)
+	data, _ := NewMatrix(dataShards, 3)
+	for r, row := range data {
+		for c := range row {
+			row[c] = byte((r*3 + c + 1) % int(gf.Size()))
+		}
+	}
+	encoded, _ := gf.MatrixMultiply(m, data)
+	for a := 0; a < len(m); a++ {
+		for b := a + 1; b < len(m); b++ {
+			var sub, subEncoded Matrix
+			for r := range m {
+				if r != a && r != b {
+					sub = append(sub, m[r])
+					subEncoded = append(subEncoded, encoded[r])
+				}
+			}
+			inv, err := gf.MatrixInvert(sub)
+			if err != nil {
+				t.Errorf("%v: lost %d,%d: %v", gf, a, b, err)
+				continue
+			}
+			decoded, _ := gf.MatrixMultiply(inv, subEncoded)
+			for r := range data {
+				if !equalBytes(decoded[r], data[r]) {
+					t.Errorf("%v: lost %d,%d: row %d expected %v, got %v", gf, a, b, r, data[r], decoded[r])
+				}
+			}
+		}
+	}
+}
+
 func TestGF_String(t *testing.T) {
 	type testrow struct {
 		field *GF
@@ -374,6 +543,48 @@ func TestGF_Exp(t *testing.T) {
 	}
 }
 
+func TestGF_Pow(t *testing.T) {
+	for _, wk := range wellknown[1:] {
+		gf := wk.field
+		m := int(gf.Size() - 1)
+		for x := uint(0); x < gf.Size(); x++ {
+			a := byte(x)
+			var expect byte = 1
+			for e := 0; e <= 3*m; e++ {
+				if actual := gf.Pow(a, e); actual != expect {
+					t.Errorf("%s: expected %d**%d=%d, got %d", wk.name, a, e, expect, actual)
+				}
+				expect = gf.Mul(expect, a)
+			}
+			if a == 0 {
+				continue
+			}
+			expect = 1
+			for e := 0; e >= -3*m; e-- {
+				if actual := gf.Pow(a, e); actual != expect {
+					t.Errorf("%s: expected %d**%d=%d, got %d", wk.name, a, e, expect, actual)
+				}
+				expect = gf.Div(expect, a)
+			}
+			for _, e := range []int{math.MaxInt, math.MinInt, math.MaxInt32, math.MinInt32} {
+				expect := gf.Pow(a, e%m)
+				if actual := gf.Pow(a, e); actual != expect {
+					t.Errorf("%s: expected %d**%d=%d, got %d", wk.name, a, e, expect, actual)
+				}
+			}
+		}
+	}
+	if p := Default.Power(7, 0); p != 1 {
+		t.Errorf("expected Power(7, 0)=1, got %d", p)
+	}
+	e := panicValue(func() {
+		Default.Pow(0, -1)
+	})
+	if e != ErrDivByZero {
+		t.Errorf("expected panic(ErrDivByZero), got %v", e)
+	}
+}
+
 func TestGF_Compare(t *testing.T) {
 	type testrow struct {
 		left, right *GF
@@ -488,6 +699,92 @@ func BenchmarkGF_Mul_256(b *testing.B) {
 	}
 }
 
+// benchSink keeps the compiler from discarding the work done by benchmarks.
+var benchSink byte
+
+// benchOperands returns 4096 random field elements; the benchmarks below
+// index it by the loop counter so that no operand is a constant.
+func benchOperands() []byte {
+	operands := make([]byte, 4096)
+	rand.New(rand.NewSource(42)).Read(operands)
+	return operands
+}
+
+func BenchmarkGF_Mul_256_operands(b *testing.B) {
+	gf := Default
+	operands := benchOperands()
+	var sum byte
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sum ^= gf.Mul(operands[i&4095], operands[(i+1)&4095])
+	}
+	benchSink = sum
+}
+
+func BenchmarkGF_MulTable_256(b *testing.B) {
+	table := Default.MulTable()
+	operands := benchOperands()
+	var sum byte
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sum ^= table[operands[i&4095]][operands[(i+1)&4095]]
+	}
+	benchSink = sum
+}
+
+func BenchmarkGF_MulRow_256(b *testing.B) {
+	row := Default.MulRow(3)
+	operands := benchOperands()
+	var sum byte
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sum ^= row[operands[i&4095]]
+	}
+	benchSink = sum
+}
+
+// The shard kernels compute out += c*in over a 4 KiB shard, as Raid6 does for
+// each data shard: one Mul per byte, one MulRow lookup per byte, and
+// MulAddSlice.
+func BenchmarkGF_ShardKernel_Mul(b *testing.B) {
+	gf := Default
+	in, out := benchOperands(), make([]byte, 4096)
+	b.SetBytes(int64(len(in)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c := in[i&4095]
+		for j, x := range in {
+			out[j] ^= gf.Mul(c, x)
+		}
+	}
+	benchSink = out[0]
+}
+
+func BenchmarkGF_ShardKernel_MulRow(b *testing.B) {
+	gf := Default
+	in, out := benchOperands(), make([]byte, 4096)
+	b.SetBytes(int64(len(in)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		row := gf.MulRow(in[i&4095])
+		for j, x := range in {
+			out[j] ^= row[x]
+		}
+	}
+	benchSink = out[0]
+}
+
+func BenchmarkGF_ShardKernel_MulAddSlice(b *testing.B) {
+	gf := Default
+	in, out := benchOperands(), make([]byte, 4096)
+	b.SetBytes(int64(len(in)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		gf.MulAddSlice(in[i&4095], in, out)
+	}
+	benchSink = out[0]
+}
+
 func BenchmarkGF_Div_256(b *testing.B) {
 	gf := Default
 	var x byte = 1
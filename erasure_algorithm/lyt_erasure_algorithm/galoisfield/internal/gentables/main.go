@@ -0,0 +1,145 @@
+// Command gentables writes the Go source of the precomputed exp/log tables for
+// the well-known fields of package galoisfield, plus full multiplication and
+// inverse tables for the GF(256) ones.
+//
+// It deliberately does not import galoisfield, so that a stale or broken
+// tables_gen.go can always be regenerated.  Run it through go generate:
+//
+//	go generate example.com/galoisfield
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+)
+
+type field struct {
+	name string
+	k    uint
+	p    uint
+	g    uint
+}
+
+// The list must match the well-known fields declared in gf.go; the package
+// tests fail if it does not.
+var fields = []field{
+	{"Poly210_g2", 2, 0x7, 2},
+	{"Poly310_g2", 3, 0xb, 2},
+	{"Poly410_g2", 4, 0x13, 2},
+	{"Poly520_g2", 5, 0x25, 2},
+	{"Poly610_g2", 6, 0x43, 2},
+	{"Poly610_g7", 6, 0x43, 7},
+	{"Poly710_g2", 7, 0x83, 2},
+	{"Poly84310_g3", 8, 0x11b, 3},
+	{"Poly84320_g2", 8, 0x11d, 2},
+}
+
+func main() {
+	out := flag.String("o", "tables_gen.go", "output file")
+	flag.Parse()
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by \"go run ./internal/gentables\"; DO NOT EDIT.\n\n")
+	buf.WriteString("package galoisfield\n\n")
+	buf.WriteString("// generatedTables returns the precomputed tables for the given parameters,\n")
+	buf.WriteString("// or nils if there are none.  The mul and inv tables are only present for\n")
+	buf.WriteString("// GF(256).\n")
+	buf.WriteString("func generatedTables(p params) (log, exp []byte, mul *[256][256]byte, inv *[256]byte) {\n")
+	buf.WriteString("\tswitch p {\n")
+	for _, f := range fields {
+		fmt.Fprintf(&buf, "\tcase params{p: %#x, k: %d, g: %d}:\n", f.p, f.k, f.g)
+		if f.k == 8 {
+			fmt.Fprintf(&buf, "\t\treturn log%[1]s[:], exp%[1]s[:], &mul%[1]s, &inv%[1]s\n", f.name)
+		} else {
+			fmt.Fprintf(&buf, "\t\treturn log%[1]s[:], exp%[1]s[:], nil, nil\n", f.name)
+		}
+	}
+	buf.WriteString("\t}\n\treturn nil, nil, nil, nil\n}\n")
+
+	for _, f := range fields {
+		n := uint(1) << f.k
+		m := n - 1
+		logTable := make([]byte, n)
+		expTable := make([]byte, 2*m)
+		var x uint = 1
+		for i := uint(0); i < m; i++ {
+			if x == 1 && i != 0 {
+				log.Fatalf("%s: %d is not a generator", f.name, f.g)
+			}
+			expTable[i] = byte(x)
+			expTable[i+m] = byte(x)
+			logTable[x] = byte(i)
+			x = mulSlow(x, f.g, f.p, f.k)
+		}
+		fmt.Fprintf(&buf, "\n// Tables for %s: GF(%d), p %#x, g %d.\n", f.name, n, f.p, f.g)
+		writeArray(&buf, "log"+f.name, logTable)
+		buf.WriteByte('\n')
+		writeArray(&buf, "exp"+f.name, expTable)
+		if f.k != 8 {
+			continue
+		}
+		fmt.Fprintf(&buf, "\nvar mul%s = [256][256]byte{\n", f.name)
+		for a := uint(0); a < n; a++ {
+			row := make([]byte, n)
+			for b := uint(0); b < n; b++ {
+				row[b] = byte(mulSlow(a, b, f.p, f.k))
+			}
+			buf.WriteString("\t{\n")
+			writeBytes(&buf, "\t\t", row)
+			buf.WriteString("\t},\n")
+		}
+		buf.WriteString("}\n")
+		inv := make([]byte, n)
+		for a := uint(1); a < n; a++ {
+			inv[a] = expTable[m-uint(logTable[a])]
+		}
+		buf.WriteByte('\n')
+		writeArray(&buf, "inv"+f.name, inv)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(*out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func writeArray(buf *bytes.Buffer, name string, data []byte) {
+	fmt.Fprintf(buf, "var %s = [%d]byte{\n", name, len(data))
+	writeBytes(buf, "\t", data)
+	buf.WriteString("}\n")
+}
+
+func writeBytes(buf *bytes.Buffer, indent string, data []byte) {
+	for i := 0; i < len(data); i += 16 {
+		buf.WriteString(indent)
+		for j := i; j < i+16 && j < len(data); j++ {
+			if j > i {
+				buf.WriteByte(' ')
+			}
+			fmt.Fprintf(buf, "0x%02x,", data[j])
+		}
+		buf.WriteByte('\n')
+	}
+}
+
+// mulSlow returns x*y mod p, for a polynomial p of degree k.
+func mulSlow(x, y, p, k uint) uint {
+	var r uint
+	for ; y != 0; y >>= 1 {
+		if y&1 != 0 {
+			r ^= x
+		}
+		x <<= 1
+		if x&(1<<k) != 0 {
+			x ^= p
+		}
+	}
+	return r
+}
@@ -0,0 +1,88 @@
+package galoisfield
+
+import (
+	"errors"
+)
+
+var (
+	ErrPointCount     = errors.New("xs and ys must have the same length")
+	ErrDuplicatePoint = errors.New("interpolation points must have distinct x coordinates")
+	ErrPointRange     = errors.New("interpolation point is not an element of the field")
+)
+
+// Interpolate returns the unique polynomial of degree less than len(xs) that
+// passes through every point (xs[i], ys[i]), using Lagrange's formula.  If
+// field is nil, Default is used.
+//
+// It returns ErrPointCount if xs and ys differ in length, ErrPointRange if any
+// coordinate is not smaller than field.Size(), or ErrDuplicatePoint if any x
+// coordinate appears more than once.
+func Interpolate(field *GF, xs, ys []byte) (Polynomial, error) {
+	if field == nil {
+		field = Default
+	}
+	if err := checkPoints(field, xs, ys); err != nil {
+		return Polynomial{}, err
+	}
+	sum := NewPolynomial(field)
+	for i := range xs {
+		// basis is 1 at xs[i] and 0 at every other xs[j].
+		basis := NewPolynomial(field, 1)
+		var denom byte = 1
+		for j := range xs {
+			if j == i {
+				continue
+			}
+			basis = basis.Mul(NewPolynomial(field, xs[j], 1))
+			denom = field.Mul(denom, field.Add(xs[i], xs[j]))
+		}
+		sum = sum.Add(basis.Scale(field.Div(ys[i], denom)))
+	}
+	return sum, nil
+}
+
+// InterpolateAt returns the value at x of the polynomial that Interpolate
+// would return, without computing its coefficients.  This takes O(n**2) time
+// rather than O(n**3).  It returns the same errors as Interpolate, and
+// ErrPointRange if x is not an element of the field.
+func InterpolateAt(field *GF, xs, ys []byte, x byte) (byte, error) {
+	if field == nil {
+		field = Default
+	}
+	if err := checkPoints(field, xs, ys); err != nil {
+		return 0, err
+	}
+	if uint(x) >= field.Size() {
+		return 0, ErrPointRange
+	}
+	var sum byte
+	for i := range xs {
+		var num, denom byte = 1, 1
+		for j := range xs {
+			if j == i {
+				continue
+			}
+			num = field.Mul(num, field.Add(x, xs[j]))
+			denom = field.Mul(denom, field.Add(xs[i], xs[j]))
+		}
+		sum = field.Add(sum, field.Mul(ys[i], field.Div(num, denom)))
+	}
+	return sum, nil
+}
+
+func checkPoints(field *GF, xs, ys []byte) error {
+	if len(xs) != len(ys) {
+		return ErrPointCount
+	}
+	var seen [256]bool
+	for i, x := range xs {
+		if uint(x) >= field.Size() || uint(ys[i]) >= field.Size() {
+			return ErrPointRange
+		}
+		if seen[x] {
+			return ErrDuplicatePoint
+		}
+		seen[x] = true
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package galoisfield
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestInterpolate(t *testing.T) {
+	prng := rand.New(rand.NewSource(42))
+	for _, field := range []*GF{Poly310_g2, Poly610_g7, Poly84310_g3, Default} {
+		for trial := 0; trial < 64; trial++ {
+			n := 1 + prng.Intn(int(field.Size())/2)
+			p := randomPolynomial(prng, field, n-1)
+			xs := make([]byte, n)
+			ys := make([]byte, n)
+			for i, x := range prng.Perm(int(field.Size()))[:n] {
+				xs[i] = byte(x)
+				ys[i] = p.Evaluate(byte(x))
+			}
+			q, err := Interpolate(field, xs, ys)
+			if err != nil {
+				t.Fatalf("%#v: unexpected error %v", field, err)
+			}
+			if !q.Equal(p) {
+				t.Errorf("%#v: expected (%v), got (%v)", field, p, q)
+			}
+			for i := 0; i < 16; i++ {
+				x := byte(prng.Intn(int(field.Size())))
+				y, err := InterpolateAt(field, xs, ys, x)
+				if err != nil {
+					t.Fatalf("%#v: unexpected error %v", field, err)
+				}
+				if expect := p.Evaluate(x); y != expect {
+					t.Errorf("%#v: (%v) at %d: expected %d, got %d", field, p, x, expect, y)
+				}
+			}
+		}
+	}
+
+	p, err := Interpolate(nil, nil, nil)
+	if err != nil || !p.IsZero() || p.Field() != Default {
+		t.Errorf("expected the zero polynomial over Default, got %#v, %v", p, err)
+	}
+}
+
+func TestInterpolate_errors(t *testing.T) {
+	if _, err := Interpolate(nil, []byte{1, 2}, []byte{3}); err != ErrPointCount {
+		t.Errorf("expected ErrPointCount, got %v", err)
+	}
+	if _, err := Interpolate(nil, []byte{1, 2, 1}, []byte{3, 4, 5}); err != ErrDuplicatePoint {
+		t.Errorf("expected ErrDuplicatePoint, got %v", err)
+	}
+	if _, err := InterpolateAt(nil, []byte{1, 2}, []byte{3}, 0); err != ErrPointCount {
+		t.Errorf("expected ErrPointCount, got %v", err)
+	}
+	if _, err := InterpolateAt(nil, []byte{7, 7}, []byte{3, 4}, 0); err != ErrDuplicatePoint {
+		t.Errorf("expected ErrDuplicatePoint, got %v", err)
+	}
+	if _, err := Interpolate(Poly310_g2, []byte{9, 1}, []byte{3, 4}); err != ErrPointRange {
+		t.Errorf("expected ErrPointRange, got %v", err)
+	}
+	if _, err := Interpolate(Poly310_g2, []byte{2, 1}, []byte{3, 8}); err != ErrPointRange {
+		t.Errorf("expected ErrPointRange, got %v", err)
+	}
+	if _, err := InterpolateAt(Poly310_g2, []byte{2, 1}, []byte{3, 4}, 8); err != ErrPointRange {
+		t.Errorf("expected ErrPointRange, got %v", err)
+	}
+}
@@ -0,0 +1,300 @@
+package galoisfield
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrNilField       = errors.New("cannot marshal a nil field")
+	ErrMalformedField = errors.New("malformed field description")
+	ErrBinaryFormat   = errors.New("binary field description must be 4 bytes")
+
+	ErrMalformedPolynomial = errors.New("malformed polynomial")
+	ErrCoefficientRange    = errors.New("coefficient is not an element of the field")
+	ErrUnknownField        = errors.New("polynomial text does not name a field")
+)
+
+// ParseField parses the output of either String or GoString and returns the
+// interned singleton for the field it describes.  Parameters that NewField
+// would reject yield the same errors here.
+func ParseField(text string) (*GF, error) {
+	text = strings.TrimSpace(text)
+	for _, wk := range wellknown[1:] {
+		if text == wk.name {
+			return wk.field, nil
+		}
+	}
+	var args []string
+	var poly bool
+	switch {
+	case strings.HasPrefix(text, "GF(") && strings.HasSuffix(text, ")"):
+		args = strings.Split(text[3:len(text)-1], ";")
+		poly = true
+	case strings.HasPrefix(text, "New(") && strings.HasSuffix(text, ")"):
+		args = strings.Split(text[4:len(text)-1], ",")
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrMalformedField, text)
+	}
+	if len(args) != 3 {
+		return nil, fmt.Errorf("%w: %q", ErrMalformedField, text)
+	}
+	n, err1 := strconv.ParseUint(strings.TrimSpace(args[0]), 0, 16)
+	g, err2 := strconv.ParseUint(strings.TrimSpace(args[2]), 0, 8)
+	var p uint64
+	var err3 error
+	if poly {
+		p, err3 = parsePolyString(args[1])
+	} else {
+		p, err3 = strconv.ParseUint(strings.TrimSpace(args[1]), 0, 16)
+	}
+	if err1 != nil || err2 != nil || err3 != nil {
+		return nil, fmt.Errorf("%w: %q", ErrMalformedField, text)
+	}
+	return NewField(uint(n), uint(p), byte(g))
+}
+
+// DecodeField parses the output of MarshalBinary and returns the interned
+// singleton for the field it describes.
+func DecodeField(data []byte) (*GF, error) {
+	if len(data) != 4 || data[0] >= 16 {
+		return nil, ErrBinaryFormat
+	}
+	n := uint(1) << data[0]
+	p := uint(data[1])<<8 | uint(data[2])
+	return NewField(n, p, data[3])
+}
+
+// parsePolyString is the inverse of BinaryPoly.String.
+func parsePolyString(s string) (uint64, error) {
+	var p uint64
+	for _, mono := range strings.Split(s, "+") {
+		var bit uint64
+		switch mono = strings.TrimSpace(mono); {
+		case mono == "1":
+			bit = 0
+		case mono == "b":
+			bit = 1
+		case strings.HasPrefix(mono, "b^"):
+			var err error
+			bit, err = strconv.ParseUint(mono[2:], 10, 5)
+			if err != nil || bit < 2 {
+				return 0, ErrMalformedField
+			}
+		default:
+			return 0, ErrMalformedField
+		}
+		if p&(1<<bit) != 0 {
+			return 0, ErrMalformedField
+		}
+		p |= 1 << bit
+	}
+	return p, nil
+}
+
+// ParsePolynomial parses the output of either String or GoString into a
+// polynomial over field.  If field is nil, the field named by the GoString
+// form is used, or Default for the String form.  It returns an error wrapping
+// ErrMalformedPolynomial if the text is malformed or repeats a degree,
+// ErrCoefficientRange if a coefficient is not smaller than field.Size(), or
+// ErrIncompatibleFields if the GoString form names a different field.
+func ParsePolynomial(field *GF, text string) (Polynomial, error) {
+	text = strings.TrimSpace(text)
+	if strings.HasPrefix(text, "NewPolynomial(") && strings.HasSuffix(text, ")") {
+		return parsePolynomialGoString(field, text)
+	}
+	if field == nil {
+		field = Default
+	}
+	if text == "" {
+		return Polynomial{}, fmt.Errorf("%w: %q", ErrMalformedPolynomial, text)
+	}
+	var coefficients []byte
+	seen := make(map[uint64]bool)
+	for _, term := range strings.Split(text, "+") {
+		term = strings.TrimSpace(term)
+		k, d, err := parseTerm(field, term)
+		if err != nil {
+			return Polynomial{}, err
+		}
+		if seen[d] {
+			return Polynomial{}, fmt.Errorf("%w: repeated degree %d in %q", ErrMalformedPolynomial, d, text)
+		}
+		seen[d] = true
+		if d >= uint64(len(coefficients)) {
+			coefficients = expand(int(d)+1, coefficients)
+		}
+		coefficients[d] = k
+	}
+	return NewPolynomial(field, coefficients...), nil
+}
+
+// parseTerm parses one term of the String form, "k", "kx", "kx^d", "x" or
+// "x^d", returning its coefficient k and degree d.
+func parseTerm(field *GF, term string) (k byte, d uint64, err error) {
+	coeff, power := term, ""
+	if i := strings.IndexByte(term, 'x'); i >= 0 {
+		coeff, power = term[:i], term[i+1:]
+		d = 1
+		if power != "" {
+			if !strings.HasPrefix(power, "^") {
+				return 0, 0, fmt.Errorf("%w: term %q", ErrMalformedPolynomial, term)
+			}
+			// Bound the degree so that a typo cannot allocate gigabytes.
+			d, err = strconv.ParseUint(power[1:], 10, 16)
+			if err != nil {
+				return 0, 0, fmt.Errorf("%w: term %q", ErrMalformedPolynomial, term)
+			}
+		}
+		if coeff == "" {
+			return 1, d, nil
+		}
+	}
+	v, err := strconv.ParseUint(coeff, 10, 16)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%w: term %q", ErrMalformedPolynomial, term)
+	}
+	if v >= uint64(field.Size()) {
+		return 0, 0, fmt.Errorf("%w: %d in %v", ErrCoefficientRange, v, field)
+	}
+	return byte(v), d, nil
+}
+
+// parsePolynomialGoString parses text of the form "NewPolynomial(field, k0,
+// k1, ...)".
+func parsePolynomialGoString(field *GF, text string) (Polynomial, error) {
+	args := text[len("NewPolynomial(") : len(text)-1]
+	// The field itself may be "New(n, p, g)", which contains commas.
+	end := strings.IndexByte(args, ',')
+	if strings.HasPrefix(strings.TrimSpace(args), "New(") {
+		end = strings.IndexByte(args, ')') + 1
+		if end < len(args) && args[end] != ',' {
+			return Polynomial{}, fmt.Errorf("%w: %q", ErrMalformedPolynomial, text)
+		}
+	}
+	if end < 0 {
+		end = len(args)
+	}
+	named, err := ParseField(args[:end])
+	if err != nil {
+		return Polynomial{}, fmt.Errorf("%w: %q: %v", ErrMalformedPolynomial, text, err)
+	}
+	if field == nil {
+		field = named
+	} else if field != named {
+		return Polynomial{}, ErrIncompatibleFields
+	}
+	var coefficients []byte
+	if end < len(args) {
+		for _, arg := range strings.Split(args[end+1:], ",") {
+			v, err := strconv.ParseUint(strings.TrimSpace(arg), 0, 16)
+			if err != nil {
+				return Polynomial{}, fmt.Errorf("%w: %q", ErrMalformedPolynomial, text)
+			}
+			if v >= uint64(field.Size()) {
+				return Polynomial{}, fmt.Errorf("%w: %d in %v", ErrCoefficientRange, v, field)
+			}
+			coefficients = append(coefficients, byte(v))
+		}
+	}
+	return NewPolynomial(field, coefficients...), nil
+}
+
+// MarshalText implements encoding.TextMarshaler.  The text is the same as the
+// output of GoString, so that it records the field as well as the
+// coefficients.  It returns ErrNilField for the zero Polynomial, which has no
+// field.
+func (a Polynomial) MarshalText() ([]byte, error) {
+	if a.field == nil {
+		return nil, ErrNilField
+	}
+	return []byte(a.GoString()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.  It accepts everything
+// that ParsePolynomial does.  If a already has a field, the text must be over
+// that field; otherwise the text must name one, as GoString does, or
+// UnmarshalText returns ErrUnknownField.
+func (a *Polynomial) UnmarshalText(text []byte) error {
+	if a.field == nil && !strings.HasPrefix(strings.TrimSpace(string(text)), "NewPolynomial(") {
+		return ErrUnknownField
+	}
+	p, err := ParsePolynomial(a.field, string(text))
+	if err != nil {
+		return err
+	}
+	*a = p
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.  The text is the same as the
+// output of String.
+func (gf *GF) MarshalText() ([]byte, error) {
+	if gf == nil {
+		return nil, ErrNilField
+	}
+	return []byte(gf.String()), nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.  The encoding is four
+// bytes: log_2(n), p as a big-endian uint16, and g.
+func (gf *GF) MarshalBinary() ([]byte, error) {
+	if gf == nil {
+		return nil, ErrNilField
+	}
+	return []byte{gf.k, byte(gf.p >> 8), byte(gf.p), gf.g}, nil
+}
+
+// MarshalJSON implements json.Marshaler.  The field is encoded as a JSON
+// string holding the output of MarshalText.
+func (gf *GF) MarshalJSON() ([]byte, error) {
+	text, err := gf.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// FieldRef refers to a field, and marshals exactly as the field does.  It is
+// unmarshaled by pointing it at the interned singleton, so use it in place of
+// a *GF in structs that record which field was used, such as the metadata of
+// an encoded object.
+//
+// *GF itself deliberately has no unmarshalers: the fields are shared
+// singletons, so decoding into one in place would corrupt every user of it.
+// FieldRef, ParseField and DecodeField are the only ways to decode a field.
+type FieldRef struct {
+	*GF
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler using ParseField.
+func (ref *FieldRef) UnmarshalText(text []byte) error {
+	gf, err := ParseField(string(text))
+	if err != nil {
+		return err
+	}
+	ref.GF = gf
+	return nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler using DecodeField.
+func (ref *FieldRef) UnmarshalBinary(data []byte) error {
+	gf, err := DecodeField(data)
+	if err != nil {
+		return err
+	}
+	ref.GF = gf
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (ref *FieldRef) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return err
+	}
+	return ref.UnmarshalText([]byte(text))
+}
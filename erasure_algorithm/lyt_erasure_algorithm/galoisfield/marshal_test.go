@@ -0,0 +1,268 @@
+package galoisfield
+
+import (
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestParseField(t *testing.T) {
+	custom := New(16, 0x19, 2)
+	for _, field := range []*GF{
+		Poly210_g2, Poly310_g2, Poly410_g2, Poly520_g2, Poly610_g2,
+		Poly610_g7, Poly710_g2, Poly84310_g3, Poly84320_g2, custom,
+	} {
+		for _, text := range []string{field.String(), field.GoString()} {
+			parsed, err := ParseField(text)
+			if err != nil {
+				t.Errorf("ParseField(%q): unexpected error %v", text, err)
+				continue
+			}
+			if parsed != field {
+				t.Errorf("ParseField(%q): expected singleton %#v, got %#v", text, field, parsed)
+			}
+		}
+		data, err := field.MarshalBinary()
+		if err != nil {
+			t.Fatalf("%#v: MarshalBinary: unexpected error %v", field, err)
+		}
+		decoded, err := DecodeField(data)
+		if err != nil {
+			t.Errorf("DecodeField(%v): unexpected error %v", data, err)
+		} else if decoded != field {
+			t.Errorf("DecodeField(%v): expected singleton %#v, got %#v", data, field, decoded)
+		}
+	}
+
+	if gf, err := ParseField("New(256, 0x11d, 2)"); gf != Poly84320_g2 || err != nil {
+		t.Errorf("ParseField: expected Poly84320_g2, got %#v, %v", gf, err)
+	}
+}
+
+func TestParseField_errors(t *testing.T) {
+	type testrow struct {
+		text   string
+		expect error
+	}
+	for _, row := range []testrow{
+		testrow{"", ErrMalformedField},
+		testrow{"Poly84320_g5", ErrMalformedField},
+		testrow{"GF(256;b^8+b^4+b^3+b^2+1)", ErrMalformedField},
+		testrow{"GF(256;b^8+b^4+b^3+c+1;2)", ErrMalformedField},
+		testrow{"GF(256;b^8+b^8+b^3+b^2+1;2)", ErrMalformedField},
+		testrow{"GF(256;b^8+b^4+b^3+b^2+1;x)", ErrMalformedField},
+		testrow{"New(256, 0x11d)", ErrMalformedField},
+		testrow{"GF(255;b^8+b^4+b^3+b^2+1;2)", ErrFieldSize},
+		testrow{"GF(256;b^8+b^4+b^3+b^2;2)", ErrReduciblePoly},
+		testrow{"GF(256;b^8+b^4+b^3+b+1;2)", ErrNotGenerator},
+		testrow{"New(16, 0x40, 2)", ErrPolyOutOfRange},
+	} {
+		gf, err := ParseField(row.text)
+		if !errors.Is(err, row.expect) {
+			t.Errorf("ParseField(%q): expected %v, got %#v, %v", row.text, row.expect, gf, err)
+		}
+	}
+	for _, data := range [][]byte{nil, {8, 1, 0x1d}, {16, 0, 0, 2}} {
+		if _, err := DecodeField(data); err != ErrBinaryFormat {
+			t.Errorf("DecodeField(%v): expected ErrBinaryFormat, got %v", data, err)
+		}
+	}
+	if _, err := DecodeField([]byte{8, 1, 0x1b, 2}); err != ErrNotGenerator {
+		t.Errorf("DecodeField: expected ErrNotGenerator, got %v", err)
+	}
+	var gf *GF
+	if _, err := gf.MarshalText(); err != ErrNilField {
+		t.Errorf("MarshalText(nil): expected ErrNilField, got %v", err)
+	}
+}
+
+func TestGF_MarshalJSON(t *testing.T) {
+	type metadata struct {
+		Field  *GF `json:"field"`
+		Shards int `json:"shards"`
+	}
+	in := metadata{Poly84310_g3, 5}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := `{"field":"GF(256;b^8+b^4+b^3+b+1;3)","shards":5}`
+	if string(data) != expect {
+		t.Errorf("expected %s, got %s", expect, data)
+	}
+
+	// Decoding into a *GF must fail rather than overwrite the singleton that
+	// the struct already points at.
+	out := metadata{Field: Default}
+	if err := json.Unmarshal(data, &out); err == nil {
+		t.Errorf("expected an error decoding into a *GF")
+	}
+	if gf := MustNew(256, 0x11d, 2); gf != Default || gf.Generator() != 2 || Default.Exp(1) != 2 {
+		t.Fatalf("decoding corrupted Default: %#v", gf)
+	}
+}
+
+func TestFieldRef(t *testing.T) {
+	type metadata struct {
+		Field  FieldRef `json:"field"`
+		Shards int      `json:"shards"`
+	}
+	custom := New(16, 0x19, 2)
+	for _, field := range []*GF{Poly84310_g3, Poly410_g2, custom} {
+		in := metadata{FieldRef{field}, 5}
+		data, err := json.Marshal(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		text, _ := json.Marshal(field)
+		if expect := `{"field":` + string(text) + `,"shards":5}`; string(data) != expect {
+			t.Errorf("expected %s, got %s", expect, data)
+		}
+		var out metadata
+		if err := json.Unmarshal(data, &out); err != nil {
+			t.Fatal(err)
+		}
+		if out.Field.GF != field || out.Shards != 5 {
+			t.Errorf("expected singleton %#v, got %#v", field, out.Field.GF)
+		}
+
+		binary, err := in.Field.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ref FieldRef
+		if err := ref.UnmarshalBinary(binary); err != nil || ref.GF != field {
+			t.Errorf("UnmarshalBinary(%v): expected singleton %#v, got %#v, %v", binary, field, ref.GF, err)
+		}
+	}
+
+	// Decoding into a FieldRef that already refers to Default repoints it,
+	// leaving Default alone.
+	out := metadata{Field: FieldRef{Default}}
+	if err := json.Unmarshal([]byte(`{"field":"GF(256;b^8+b^4+b^3+b+1;3)"}`), &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Field.GF != Poly84310_g3 || Default != MustNew(256, 0x11d, 2) || Default.Generator() != 2 {
+		t.Errorf("expected Poly84310_g3 and an intact Default, got %#v and %#v", out.Field.GF, Default)
+	}
+
+	if err := json.Unmarshal([]byte(`{"field":"GF(7;b^2+1;2)"}`), &out); !errors.Is(err, ErrFieldSize) {
+		t.Errorf("expected ErrFieldSize, got %v", err)
+	}
+	if _, err := json.Marshal(metadata{}); !errors.Is(err, ErrNilField) {
+		t.Errorf("expected ErrNilField, got %v", err)
+	}
+}
+
+func TestParsePolynomial(t *testing.T) {
+	prng := rand.New(rand.NewSource(42))
+	custom := New(16, 0x19, 2)
+	for _, field := range []*GF{Poly210_g2, Poly410_g2, Poly84310_g3, Default, custom} {
+		for trial := 0; trial < 64; trial++ {
+			p := randomPolynomial(prng, field, prng.Intn(10))
+			parsed, err := ParsePolynomial(field, p.String())
+			if err != nil || !parsed.Equal(p) {
+				t.Errorf("ParsePolynomial(%#v, %q): expected (%v), got (%v), %v", field, p.String(), p, parsed, err)
+			}
+			parsed, err = ParsePolynomial(nil, p.GoString())
+			if err != nil || !parsed.Equal(p) {
+				t.Errorf("ParsePolynomial(nil, %q): expected %#v, got %#v, %v", p.GoString(), p, parsed, err)
+			}
+		}
+	}
+
+	type testrow struct {
+		text   string
+		expect Polynomial
+	}
+	for _, row := range []testrow{
+		testrow{"0", NewPolynomial(nil)},
+		testrow{" x ", NewPolynomial(nil, 0, 1)},
+		testrow{"7+x^3", NewPolynomial(nil, 7, 0, 0, 1)},
+		testrow{"x^0 + 2x^1", NewPolynomial(nil, 1, 2)},
+		testrow{"NewPolynomial(Poly84320_g2, 7, 0x01, 3)", NewPolynomial(nil, 7, 1, 3)},
+	} {
+		p, err := ParsePolynomial(nil, row.text)
+		if err != nil || !p.Equal(row.expect) {
+			t.Errorf("ParsePolynomial(%q): expected (%v), got (%v), %v", row.text, row.expect, p, err)
+		}
+	}
+}
+
+func TestParsePolynomial_errors(t *testing.T) {
+	type testrow struct {
+		field  *GF
+		text   string
+		expect error
+	}
+	for _, row := range []testrow{
+		testrow{nil, "", ErrMalformedPolynomial},
+		testrow{nil, "3x^2 +", ErrMalformedPolynomial},
+		testrow{nil, "3y^2", ErrMalformedPolynomial},
+		testrow{nil, "3x2", ErrMalformedPolynomial},
+		testrow{nil, "3 x", ErrMalformedPolynomial},
+		testrow{nil, "x^-1", ErrMalformedPolynomial},
+		testrow{nil, "x^99999", ErrMalformedPolynomial},
+		testrow{nil, "x + 0x", ErrMalformedPolynomial},
+		testrow{nil, "1 + x^2 + 3", ErrMalformedPolynomial},
+		testrow{nil, "256x", ErrCoefficientRange},
+		testrow{Poly410_g2, "16x + 1", ErrCoefficientRange},
+		testrow{nil, "NewPolynomial(Poly84320_g5, 1)", ErrMalformedPolynomial},
+		testrow{nil, "NewPolynomial(New(16, 0x19, 2)x, 1)", ErrMalformedPolynomial},
+		testrow{nil, "NewPolynomial(Poly84320_g2, 1, y)", ErrMalformedPolynomial},
+		testrow{nil, "NewPolynomial(Poly410_g2, 1, 16)", ErrCoefficientRange},
+		testrow{Poly410_g2, "NewPolynomial(Poly84320_g2, 1)", ErrIncompatibleFields},
+	} {
+		p, err := ParsePolynomial(row.field, row.text)
+		if !errors.Is(err, row.expect) {
+			t.Errorf("ParsePolynomial(%#v, %q): expected %v, got (%v), %v", row.field, row.text, row.expect, p, err)
+		}
+	}
+}
+
+func TestPolynomial_MarshalJSON(t *testing.T) {
+	type vector struct {
+		Generator Polynomial `json:"generator"`
+	}
+	in := vector{NewPolynomial(Poly410_g2, 7, 1, 15)}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := `{"generator":"NewPolynomial(Poly410_g2, 7, 1, 15)"}`
+	if string(data) != expect {
+		t.Errorf("expected %s, got %s", expect, data)
+	}
+
+	// The field round-trips even without one to start from.
+	var out vector
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if !out.Generator.Equal(in.Generator) || out.Generator.Field() != Poly410_g2 {
+		t.Errorf("expected %#v, got %#v", in.Generator, out.Generator)
+	}
+
+	// The String form names no field, so it needs one to start from.
+	var def vector
+	if err := json.Unmarshal([]byte(`{"generator":"15x^2 + x + 7"}`), &def); err != ErrUnknownField {
+		t.Errorf("expected ErrUnknownField, got %v", err)
+	}
+	out = vector{NewPolynomial(Poly410_g2)}
+	if err := json.Unmarshal([]byte(`{"generator":"15x^2 + x + 7"}`), &out); err != nil {
+		t.Fatal(err)
+	}
+	if !out.Generator.Equal(in.Generator) {
+		t.Errorf("expected %#v, got %#v", in.Generator, out.Generator)
+	}
+	if err := json.Unmarshal(data, &vector{NewPolynomial(Default)}); err != ErrIncompatibleFields {
+		t.Errorf("expected ErrIncompatibleFields, got %v", err)
+	}
+	if _, err := json.Marshal(vector{}); !errors.Is(err, ErrNilField) {
+		t.Errorf("expected ErrNilField, got %v", err)
+	}
+	if err := json.Unmarshal([]byte(`{"generator":"16x"}`), &out); !errors.Is(err, ErrCoefficientRange) {
+		t.Errorf("expected ErrCoefficientRange, got %v", err)
+	}
+}
@@ -5,71 +5,106 @@ import (
 	"fmt"
 )
 
-type matrix [][]byte
+// Matrix is a matrix of field elements, stored as a slice of rows.  Every row
+// must have the same length.  The matrix itself carries no field; the GF
+// methods that operate on matrices supply it.
+type Matrix [][]byte
 
 var (
-	errInvalidRowSize  = errors.New("invalid row size")
-	errInvalidColSize  = errors.New("invalid column size")
-	errColSizeMismatch = errors.New("column size is not the same for all rows")
-	errMatrixSize      = errors.New("matrix sizes do not match")
-	errSingular        = errors.New("matrix is singular")
-	errNotSquare       = errors.New("only square matrices can be inverted")
+	ErrInvalidRowSize  = errors.New("invalid row size")
+	ErrInvalidColSize  = errors.New("invalid column size")
+	ErrColSizeMismatch = errors.New("column size is not the same for all rows")
+	ErrMatrixSize      = errors.New("matrix sizes do not match")
+	ErrSingular        = errors.New("matrix is singular")
+	ErrNotSquare       = errors.New("only square matrices can be inverted")
+	ErrCauchyPoints    = errors.New("Cauchy matrix points must be distinct field elements")
+	ErrNoSolution      = errors.New("linear system has no solution")
 )
 
-func (gf *GF) MatrixMultiply(m, right matrix) (matrix, error) {
-	if len(m[0]) != len(right) {
-		return nil, fmt.Errorf("columns on left (%d) is different than rows on right (%d)", len(m[0]), len(right))
+// MatrixMultiply returns the product m*right.  It returns an error wrapping
+// ErrMatrixSize if the columns of m do not match the rows of right.
+func (gf *GF) MatrixMultiply(m, right Matrix) (Matrix, error) {
+	if len(m) == 0 || len(right) == 0 || len(m[0]) != len(right) {
+		return nil, fmt.Errorf("%w: columns on left (%d) is different than rows on right (%d)", ErrMatrixSize, m.Cols(), right.Rows())
 	}
-	result, _ := newMatrix(len(m), len(right[0]))
+	result, _ := NewMatrix(len(m), len(right[0]))
 	for r, row := range result {
-		for c := range row {
-			var value byte
-			for i := range m[0] {
-				value ^= gf.Mul(m[r][i], right[i][c])
-			}
-			result[r][c] = value
+		for i := range m[0] {
+			gf.MulAddSlice(m[r][i], right[i], row)
 		}
 	}
 	return result, nil
 }
 
-func newMatrixData(data [][]byte) (matrix, error) {
-	m := matrix(data)
-	err := m.Check()
-	if err != nil {
+// MatrixFromRows returns a matrix holding a copy of rows.  It returns an
+// error if rows is empty or ragged.
+func MatrixFromRows(rows [][]byte) (Matrix, error) {
+	if err := Matrix(rows).Check(); err != nil {
 		return nil, err
 	}
+	m, _ := NewMatrix(len(rows), len(rows[0]))
+	for r, row := range rows {
+		copy(m[r], row)
+	}
 	return m, nil
 }
 
-func newMatrix(rows, cols int) (matrix, error) {
-	m := matrix(make([][]byte, rows))
+// NewMatrix returns a rows×cols matrix of zeros.  It returns ErrInvalidRowSize
+// or ErrInvalidColSize unless both dimensions are positive.
+func NewMatrix(rows, cols int) (Matrix, error) {
+	if rows <= 0 {
+		return nil, ErrInvalidRowSize
+	}
+	if cols <= 0 {
+		return nil, ErrInvalidColSize
+	}
+	m := Matrix(make([][]byte, rows))
+	data := make([]byte, rows*cols)
 	for i := range m {
-		m[i] = make([]byte, cols)
+		m[i] = data[i*cols : (i+1)*cols : (i+1)*cols]
 	}
 	return m, nil
 }
 
-func (m matrix) Check() error {
+// Rows returns the number of rows in m.
+func (m Matrix) Rows() int { return len(m) }
+
+// Cols returns the number of columns in m.
+func (m Matrix) Cols() int {
+	if len(m) == 0 {
+		return 0
+	}
+	return len(m[0])
+}
+
+// At returns the element at row r and column c.
+func (m Matrix) At(r, c int) byte { return m[r][c] }
+
+// Set sets the element at row r and column c to v.
+func (m Matrix) Set(r, c int, v byte) { m[r][c] = v }
+
+// Check returns an error if m is empty or ragged.
+func (m Matrix) Check() error {
 	rows := len(m)
 	if rows <= 0 {
-		return errInvalidRowSize
+		return ErrInvalidRowSize
 	}
 	cols := len(m[0])
 	if cols <= 0 {
-		return errInvalidColSize
+		return ErrInvalidColSize
 	}
 
 	for _, col := range m {
 		if len(col) != cols {
-			return errColSizeMismatch
+			return ErrColSizeMismatch
 		}
 	}
 	return nil
 }
 
-func (m matrix) SubMatrix(rmin, cmin, rmax, cmax int) (matrix, error) {
-	result, err := newMatrix(rmax-rmin, cmax-cmin)
+// SubMatrix returns a copy of rows [rmin, rmax) and columns [cmin, cmax) of m.
+func (m Matrix) SubMatrix(rmin, cmin, rmax, cmax int) (Matrix, error) {
+	result, err := NewMatrix(rmax-rmin, cmax-cmin)
 	if err != nil {
 		return nil, err
 	}
@@ -81,24 +116,27 @@ func (m matrix) SubMatrix(rmin, cmin, rmax, cmax int) (matrix, error) {
 	return result, nil
 }
 
-func (m matrix) SwapRows(r1, r2 int) error {
+// SwapRows exchanges rows r1 and r2 of m in place.
+func (m Matrix) SwapRows(r1, r2 int) error {
 	if r1 < 0 || len(m) <= r1 || r2 < 0 || len(m) <= r2 {
-		return errInvalidRowSize
+		return ErrInvalidRowSize
 	}
 	m[r2], m[r1] = m[r1], m[r2]
 	return nil
 }
 
-func (m matrix) IsSquare() bool {
-	return len(m) == len(m[0])
+// IsSquare returns true iff m is nonempty and has as many rows as columns.
+func (m Matrix) IsSquare() bool {
+	return len(m) > 0 && len(m) == len(m[0])
 }
 
-func (m matrix) Augment(right matrix) (matrix, error) {
+// Augment returns m with the columns of right appended to each row.
+func (m Matrix) Augment(right Matrix) (Matrix, error) {
 	if len(m) != len(right) {
-		return nil, errMatrixSize
+		return nil, ErrMatrixSize
 	}
 
-	result, _ := newMatrix(len(m), len(m[0])+len(right[0]))
+	result, _ := NewMatrix(len(m), len(m[0])+len(right[0]))
 	for r, row := range m {
 		for c := range row {
 			result[r][c] = m[r][c]
@@ -111,62 +149,141 @@ func (m matrix) Augment(right matrix) (matrix, error) {
 	return result, nil
 }
 
-func (gf *GF) gaussianElimination(m matrix) error {
-	rows := len(m)
-	columns := len(m[0])
-	for r := 0; r < rows; r++ {
-		if m[r][r] == 0 {
-			for rowBelow := r + 1; rowBelow < rows; rowBelow++ {
-				if m[rowBelow][r] != 0 {
-					err := m.SwapRows(r, rowBelow)
-					if err != nil {
-						return err
-					}
-					break
-				}
-			}
-		}
-		// If we couldn't find one, the matrix is singular.
-		if m[r][r] == 0 {
-			return errSingular
-		}
-		// Scale to 1.
-		if m[r][r] != 1 {
-			scale := gf.Div(1, m[r][r])
-			for c := 0; c < columns; c++ {
-				m[r][c] = gf.Mul(m[r][c], scale)
-			}
+// RowReduce returns the reduced row echelon form of m, together with the
+// column of the leading 1 in each nonzero row, in increasing order.  Rows of
+// zeros are left at the bottom.  m itself is not modified.  It returns an
+// error if m is empty or ragged.
+func (gf *GF) RowReduce(m Matrix) (Matrix, []int, error) {
+	work, err := MatrixFromRows(m)
+	if err != nil {
+		return nil, nil, err
+	}
+	pivots, _ := gf.rowReduce(work, work.Cols())
+	return work, pivots, nil
+}
+
+// Rank returns the rank of m, i.e. the number of linearly independent rows.
+func (gf *GF) Rank(m Matrix) (int, error) {
+	_, pivots, err := gf.RowReduce(m)
+	return len(pivots), err
+}
+
+// Determinant returns the determinant of the square matrix m, which is zero
+// iff m is singular.  It returns ErrNotSquare if m is not square.
+func (gf *GF) Determinant(m Matrix) (byte, error) {
+	work, err := MatrixFromRows(m)
+	if err != nil {
+		return 0, err
+	}
+	if !work.IsSquare() {
+		return 0, ErrNotSquare
+	}
+	pivots, det := gf.rowReduce(work, work.Cols())
+	if len(pivots) < work.Rows() {
+		return 0, nil
+	}
+	return det, nil
+}
+
+// NullSpace returns a basis for the null space of m, i.e. of the vectors x
+// such that m*x == 0, as the rows of a matrix with m.Cols() columns.  It
+// returns nil if only the zero vector qualifies.
+func (gf *GF) NullSpace(m Matrix) (Matrix, error) {
+	rref, pivots, err := gf.RowReduce(m)
+	if err != nil {
+		return nil, err
+	}
+	cols := rref.Cols()
+	isPivot := make([]bool, cols)
+	for _, c := range pivots {
+		isPivot[c] = true
+	}
+	// Each free column gives one basis vector: set that variable to 1, and
+	// solve for the pivot variables.  (Subtraction is the same as addition
+	// in the Galois field.)
+	var basis Matrix
+	for free := 0; free < cols; free++ {
+		if isPivot[free] {
+			continue
 		}
-		// Make everything below the 1 be a 0 by subtracting
-		// a multiple of it.  (Subtraction and addition are
-		// both exclusive or in the Galois field.)
-		for rowBelow := r + 1; rowBelow < rows; rowBelow++ {
-			if m[rowBelow][r] != 0 {
-				scale := m[rowBelow][r]
-				for c := 0; c < columns; c++ {
-					m[rowBelow][c] ^= gf.Mul(scale, m[r][c])
-				}
-			}
+		v := make([]byte, cols)
+		v[free] = 1
+		for r, c := range pivots {
+			v[c] = rref[r][free]
 		}
+		basis = append(basis, v)
 	}
+	return basis, nil
+}
 
-	// Now clear the part above the main diagonal.
-	for d := 0; d < rows; d++ {
-		for rowAbove := 0; rowAbove < d; rowAbove++ {
-			if m[rowAbove][d] != 0 {
-				scale := m[rowAbove][d]
-				for c := 0; c < columns; c++ {
-					m[rowAbove][c] ^= gf.Mul(scale, m[d][c])
-				}
+// Solve returns a vector x such that a*x == b.  If the system has many
+// solutions, Solve returns the one whose free variables are all zero; the
+// others are x plus any combination of the rows of NullSpace(a).  It returns
+// ErrMatrixSize if len(b) differs from a.Rows(), or ErrNoSolution if the
+// system is inconsistent.
+func (gf *GF) Solve(a Matrix, b []byte) ([]byte, error) {
+	if err := a.Check(); err != nil {
+		return nil, err
+	}
+	if len(b) != a.Rows() {
+		return nil, ErrMatrixSize
+	}
+	cols := a.Cols()
+	work, _ := NewMatrix(a.Rows(), cols+1)
+	for r, row := range a {
+		copy(work[r], row)
+		work[r][cols] = b[r]
+	}
+	pivots, _ := gf.rowReduce(work, cols)
+	for r := len(pivots); r < work.Rows(); r++ {
+		if work[r][cols] != 0 {
+			return nil, ErrNoSolution
+		}
+	}
+	x := make([]byte, cols)
+	for r, c := range pivots {
+		x[c] = work[r][cols]
+	}
+	return x, nil
+}
 
+// rowReduce transforms m in place into reduced row echelon form, choosing
+// pivots only among the first cols columns.  It returns the pivot columns and
+// the product of the pivots, which is the determinant of a square m of full
+// rank.  Row swaps do not change the sign of a determinant in GF(2**k).
+func (gf *GF) rowReduce(m Matrix, cols int) (pivots []int, det byte) {
+	det = 1
+	r := 0
+	for c := 0; c < cols && r < len(m); c++ {
+		pivot := -1
+		for below := r; below < len(m); below++ {
+			if m[below][c] != 0 {
+				pivot = below
+				break
+			}
+		}
+		if pivot < 0 {
+			continue
+		}
+		m[r], m[pivot] = m[pivot], m[r]
+		det = gf.Mul(det, m[r][c])
+		if m[r][c] != 1 {
+			gf.MulSlice(gf.Inv(m[r][c]), m[r], m[r])
+		}
+		for other := range m {
+			if other != r && m[other][c] != 0 {
+				gf.MulAddSlice(m[other][c], m[r], m[other])
 			}
 		}
+		pivots = append(pivots, c)
+		r++
 	}
-	return nil
+	return pivots, det
 }
 
-func identityMatrix(size int) (matrix, error) {
-	m, err := newMatrix(size, size)
+// Identity returns the size×size identity matrix.
+func Identity(size int) (Matrix, error) {
+	m, err := NewMatrix(size, size)
 	if err != nil {
 		return nil, err
 	}
@@ -176,23 +293,97 @@ func identityMatrix(size int) (matrix, error) {
 	return m, nil
 }
 
-func (gf *GF) MatrixInvert(m matrix) (matrix, error) {
+// MatrixInvert returns the inverse of m, or ErrNotSquare or ErrSingular if m
+// has none.  It returns an error if m is empty or ragged.
+func (gf *GF) MatrixInvert(m Matrix) (Matrix, error) {
+	if err := m.Check(); err != nil {
+		return nil, err
+	}
 	if !m.IsSquare() {
-		return nil, errNotSquare
+		return nil, ErrNotSquare
 	}
 
+	// Reducing [m | I] turns the left half into I iff m is invertible, and
+	// the right half into the inverse.
 	size := len(m)
-	work, _ := identityMatrix(size)
+	work, _ := Identity(size)
 	work, _ = m.Augment(work)
-	err := gf.gaussianElimination(work)
+	if pivots, _ := gf.rowReduce(work, size); len(pivots) < size {
+		return nil, ErrSingular
+	}
+	return work.SubMatrix(0, size, size, size*2)
+}
+
+// VandermondeMatrix returns the rows×cols matrix whose element at row r and
+// column c is r**c, treating r as a field element.  Any cols rows of it form
+// an invertible matrix, since the rows are distinct powers of distinct
+// points.  It returns ErrInvalidRowSize if rows exceeds the field size.
+func (gf *GF) VandermondeMatrix(rows, cols int) (Matrix, error) {
+	if uint(rows) > gf.Size() {
+		return nil, ErrInvalidRowSize
+	}
+	m, err := NewMatrix(rows, cols)
 	if err != nil {
 		return nil, err
 	}
-	return work.SubMatrix(0, size, size, size*2)
+	for r := range m {
+		for c := range m[r] {
+			m[r][c] = gf.Pow(byte(r), c)
+		}
+	}
+	return m, nil
 }
 
-func (gf *GF) Raid6EncoderMatrix(rows, cols int) (matrix, error) {
-	m, err := newMatrix(rows, cols)
+// SystematicVandermondeMatrix returns VandermondeMatrix(rows, cols)
+// multiplied by the inverse of its top cols×cols square, so that the top
+// square is the identity and data passes through encoding unchanged.  Any
+// cols rows of it still form an invertible matrix.  It returns
+// ErrInvalidRowSize if rows is less than cols or exceeds the field size.
+func (gf *GF) SystematicVandermondeMatrix(rows, cols int) (Matrix, error) {
+	if rows < cols {
+		return nil, ErrInvalidRowSize
+	}
+	v, err := gf.VandermondeMatrix(rows, cols)
+	if err != nil {
+		return nil, err
+	}
+	top, _ := v.SubMatrix(0, 0, cols, cols)
+	inv, err := gf.MatrixInvert(top)
+	if err != nil {
+		return nil, err
+	}
+	return gf.MatrixMultiply(v, inv)
+}
+
+// CauchyMatrix returns the len(xs)×len(ys) matrix whose element at row i and
+// column j is 1/(xs[i] + ys[j]).  Every square submatrix of it is invertible,
+// so stacking it below an identity matrix gives a systematic encoding matrix
+// with the same property as SystematicVandermondeMatrix.  It returns
+// ErrCauchyPoints unless xs and ys together hold distinct field elements.
+func (gf *GF) CauchyMatrix(xs, ys []byte) (Matrix, error) {
+	m, err := NewMatrix(len(xs), len(ys))
+	if err != nil {
+		return nil, err
+	}
+	var seen [256]bool
+	for _, v := range append(append([]byte(nil), xs...), ys...) {
+		if uint(v) >= gf.Size() || seen[v] {
+			return nil, ErrCauchyPoints
+		}
+		seen[v] = true
+	}
+	for i, x := range xs {
+		for j, y := range ys {
+			m[i][j] = gf.Inv(gf.Add(x, y))
+		}
+	}
+	return m, nil
+}
+
+// Raid6EncoderMatrix returns the rows×cols encoding matrix used by Raid6: the
+// identity for the data rows, then the P (all ones) and Q (squares) rows.
+func (gf *GF) Raid6EncoderMatrix(rows, cols int) (Matrix, error) {
+	m, err := NewMatrix(rows, cols)
 	if err != nil {
 		return nil, err
 	}
@@ -205,15 +396,14 @@ func (gf *GF) Raid6EncoderMatrix(rows, cols int) (matrix, error) {
 	}
 	// set the q row
 	for c := 0; c < cols; c++ {
-		m[rows-1][c] ^= gf.Power(byte(c+1), 2)
+		m[rows-1][c] ^= gf.Pow(byte(c+1), 2)
 	}
 	return m, nil
 }
 
+// Power returns a**n.
+//
+// Deprecated: use Pow.
 func (gf *GF) Power(a byte, n int) byte {
-	res := a
-	for i := 1; i < n; i++ {
-		res = gf.Mul(res, a)
-	}
-	return res
+	return gf.Pow(a, n)
 }
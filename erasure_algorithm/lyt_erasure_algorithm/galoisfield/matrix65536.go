@@ -0,0 +1,205 @@
+package galoisfield
+
+import (
+	"fmt"
+)
+
+// Matrix65536 is the GF65536 counterpart of Matrix: a matrix of uint16 field
+// elements, stored as a slice of rows of the same length.
+type Matrix65536 [][]uint16
+
+// NewMatrix65536 returns a rows×cols matrix of zeros.  It returns
+// ErrInvalidRowSize or ErrInvalidColSize unless both dimensions are positive.
+func NewMatrix65536(rows, cols int) (Matrix65536, error) {
+	if rows <= 0 {
+		return nil, ErrInvalidRowSize
+	}
+	if cols <= 0 {
+		return nil, ErrInvalidColSize
+	}
+	m := Matrix65536(make([][]uint16, rows))
+	data := make([]uint16, rows*cols)
+	for i := range m {
+		m[i] = data[i*cols : (i+1)*cols : (i+1)*cols]
+	}
+	return m, nil
+}
+
+// Matrix65536FromRows returns a matrix holding a copy of rows.  It returns an
+// error if rows is empty or ragged.
+func Matrix65536FromRows(rows [][]uint16) (Matrix65536, error) {
+	if err := Matrix65536(rows).Check(); err != nil {
+		return nil, err
+	}
+	m, _ := NewMatrix65536(len(rows), len(rows[0]))
+	for r, row := range rows {
+		copy(m[r], row)
+	}
+	return m, nil
+}
+
+// Identity65536 returns the size×size identity matrix.
+func Identity65536(size int) (Matrix65536, error) {
+	m, err := NewMatrix65536(size, size)
+	if err != nil {
+		return nil, err
+	}
+	for i := range m {
+		m[i][i] = 1
+	}
+	return m, nil
+}
+
+// Rows returns the number of rows in m.
+func (m Matrix65536) Rows() int { return len(m) }
+
+// Cols returns the number of columns in m.
+func (m Matrix65536) Cols() int {
+	if len(m) == 0 {
+		return 0
+	}
+	return len(m[0])
+}
+
+// At returns the element at row r and column c.
+func (m Matrix65536) At(r, c int) uint16 { return m[r][c] }
+
+// Set sets the element at row r and column c to v.
+func (m Matrix65536) Set(r, c int, v uint16) { m[r][c] = v }
+
+// Check returns an error if m is empty or ragged.
+func (m Matrix65536) Check() error {
+	if len(m) == 0 {
+		return ErrInvalidRowSize
+	}
+	cols := len(m[0])
+	if cols == 0 {
+		return ErrInvalidColSize
+	}
+	for _, row := range m {
+		if len(row) != cols {
+			return ErrColSizeMismatch
+		}
+	}
+	return nil
+}
+
+// IsSquare returns true iff m is nonempty and has as many rows as columns.
+func (m Matrix65536) IsSquare() bool {
+	return len(m) > 0 && len(m) == len(m[0])
+}
+
+// MatrixMultiply returns the product m*right.  It returns an error wrapping
+// ErrMatrixSize if the columns of m do not match the rows of right.
+func (gf *GF65536) MatrixMultiply(m, right Matrix65536) (Matrix65536, error) {
+	if len(m) == 0 || len(right) == 0 || len(m[0]) != len(right) {
+		return nil, fmt.Errorf("%w: columns on left (%d) is different than rows on right (%d)", ErrMatrixSize, m.Cols(), right.Rows())
+	}
+	result, _ := NewMatrix65536(len(m), len(right[0]))
+	for r, row := range result {
+		for c := range row {
+			var value uint16
+			for i := range m[0] {
+				value ^= gf.Mul(m[r][i], right[i][c])
+			}
+			result[r][c] = value
+		}
+	}
+	return result, nil
+}
+
+// MatrixInvert returns the inverse of m, or ErrNotSquare or ErrSingular if m
+// has none.  It returns an error if m is empty or ragged.
+func (gf *GF65536) MatrixInvert(m Matrix65536) (Matrix65536, error) {
+	if err := m.Check(); err != nil {
+		return nil, err
+	}
+	if !m.IsSquare() {
+		return nil, ErrNotSquare
+	}
+
+	size := len(m)
+	work, _ := NewMatrix65536(size, size*2)
+	for r := range m {
+		copy(work[r], m[r])
+		work[r][size+r] = 1
+	}
+	err := gf.gaussianElimination(work)
+	if err != nil {
+		return nil, err
+	}
+	result, _ := NewMatrix65536(size, size)
+	for r := range result {
+		copy(result[r], work[r][size:])
+	}
+	return result, nil
+}
+
+func (gf *GF65536) gaussianElimination(m Matrix65536) error {
+	rows := len(m)
+	for r := 0; r < rows; r++ {
+		if m[r][r] == 0 {
+			for rowBelow := r + 1; rowBelow < rows; rowBelow++ {
+				if m[rowBelow][r] != 0 {
+					m[r], m[rowBelow] = m[rowBelow], m[r]
+					break
+				}
+			}
+		}
+		// If we couldn't find one, the matrix is singular.
+		if m[r][r] == 0 {
+			return ErrSingular
+		}
+		// Scale to 1.
+		if m[r][r] != 1 {
+			scale := gf.Inv(m[r][r])
+			for c := range m[r] {
+				m[r][c] = gf.Mul(m[r][c], scale)
+			}
+		}
+		// Clear the rest of the column, above and below the 1.
+		for other := 0; other < rows; other++ {
+			if other == r || m[other][r] == 0 {
+				continue
+			}
+			scale := m[other][r]
+			for c := range m[other] {
+				m[other][c] ^= gf.Mul(scale, m[r][c])
+			}
+		}
+	}
+	return nil
+}
+
+// maxRaid6Cols16 is the most data columns that GF65536.Raid6EncoderMatrix
+// accepts, so that the whole stripe has at most 65535 shards.
+const maxRaid6Cols16 = gf16Order - 2
+
+// Raid6EncoderMatrix is the GF65536 counterpart of GF.Raid6EncoderMatrix.  It
+// accepts up to 65533 data columns, and returns ErrInvalidColSize beyond that
+// or ErrInvalidRowSize unless there is room for the P and Q rows.
+func (gf *GF65536) Raid6EncoderMatrix(rows, cols int) (Matrix65536, error) {
+	if cols <= 0 || cols > maxRaid6Cols16 {
+		return nil, ErrInvalidColSize
+	}
+	if rows < cols+2 {
+		return nil, ErrInvalidRowSize
+	}
+	m, err := NewMatrix65536(rows, cols)
+	if err != nil {
+		return nil, err
+	}
+	for c := 0; c < cols; c++ {
+		m[c][c] ^= 1
+	}
+	// set the p row
+	for c := 0; c < cols; c++ {
+		m[rows-2][c] ^= 1
+	}
+	// set the q row
+	for c := 0; c < cols; c++ {
+		x := uint16(c + 1)
+		m[rows-1][c] ^= gf.Mul(x, x)
+	}
+	return m, nil
+}
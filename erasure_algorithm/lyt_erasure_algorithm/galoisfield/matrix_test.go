@@ -1,14 +1,16 @@
 package galoisfield
 
 import (
+	"errors"
 	"fmt"
+	"math/rand"
 	"testing"
 )
 
 func TestNewMatrix(t *testing.T) {
 	row := 3
 	col := 4
-	rNewMatrix, err := newMatrix(row, col)
+	rNewMatrix, err := NewMatrix(row, col)
 	if err != nil {
 		t.Errorf("Test error: creating Matrix")
 	}
@@ -16,12 +18,12 @@ func TestNewMatrix(t *testing.T) {
 }
 
 func TestMatrixMultiply(t *testing.T) {
-	m1, err := newMatrixData(
+	m1, err := MatrixFromRows(
 		[][]byte{
 			{1, 1},
 			{1, 1},
 		})
-	m2, err := newMatrixData(
+	m2, err := MatrixFromRows(
 		[][]byte{
 			{1, 1},
 			{1, 1},
@@ -36,7 +38,7 @@ func TestMatrixMultiply(t *testing.T) {
 }
 
 func TestMartrixInverse(t *testing.T) {
-	m, err := newMatrixData(
+	m, err := MatrixFromRows(
 		[][]byte{
 			{2, 0, 0},
 			{0, 1, 0},
@@ -95,3 +97,361 @@ func TestRaid6LossReconstruct(t *testing.T) {
 	fmt.Println("Reconstruct data:", reconstruct_data)
 
 }
+
+func TestMatrix_constructors(t *testing.T) {
+	m, err := NewMatrix(2, 3)
+	if err != nil || m.Rows() != 2 || m.Cols() != 3 {
+		t.Fatalf("NewMatrix(2, 3): expected 2x3, got %dx%d, %v", m.Rows(), m.Cols(), err)
+	}
+	m.Set(1, 2, 7)
+	if m.At(1, 2) != 7 || m.At(0, 2) != 0 {
+		t.Errorf("Set(1, 2, 7): got %v", m)
+	}
+	// Rows must not share storage past their ends.
+	m[0] = append(m[0], 9)
+	if m.At(1, 0) != 0 {
+		t.Errorf("appending to row 0 clobbered row 1: %v", m)
+	}
+
+	type testrow struct {
+		rows, cols int
+		expect     error
+	}
+	for _, row := range []testrow{
+		testrow{0, 3, ErrInvalidRowSize},
+		testrow{-1, 3, ErrInvalidRowSize},
+		testrow{3, 0, ErrInvalidColSize},
+	} {
+		if _, err := NewMatrix(row.rows, row.cols); err != row.expect {
+			t.Errorf("NewMatrix(%d, %d): expected %v, got %v", row.rows, row.cols, row.expect, err)
+		}
+	}
+
+	rows := [][]byte{{1, 2}, {3, 4}}
+	m, err = MatrixFromRows(rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows[0][0] = 9
+	if m.At(0, 0) != 1 {
+		t.Errorf("MatrixFromRows did not copy its input")
+	}
+	for _, rows := range [][][]byte{nil, {{}}, {{1, 2}, {3}}} {
+		if _, err := MatrixFromRows(rows); err == nil {
+			t.Errorf("MatrixFromRows(%v): expected an error", rows)
+		}
+	}
+
+	id, err := Identity(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for r := 0; r < 3; r++ {
+		for c := 0; c < 3; c++ {
+			expect := byte(0)
+			if r == c {
+				expect = 1
+			}
+			if id.At(r, c) != expect {
+				t.Errorf("Identity(3): got %v", id)
+			}
+		}
+	}
+}
+
+func TestMatrix_errors(t *testing.T) {
+	field := fields[0]
+	a, _ := NewMatrix(2, 3)
+	if _, err := field.MatrixMultiply(a, a); !errors.Is(err, ErrMatrixSize) {
+		t.Errorf("expected ErrMatrixSize, got %v", err)
+	}
+	if _, err := field.MatrixInvert(a); err != ErrNotSquare {
+		t.Errorf("expected ErrNotSquare, got %v", err)
+	}
+	for _, m := range []Matrix{nil, {}, {{}}, {{1, 2}, {3}}} {
+		if _, err := field.MatrixInvert(m); err == nil {
+			t.Errorf("MatrixInvert(%v): expected an error", m)
+		}
+		if m.IsSquare() && len(m) == 0 {
+			t.Errorf("expected the empty matrix not to be square")
+		}
+	}
+	if _, err := field.MatrixInvert(nil); err != ErrInvalidRowSize {
+		t.Errorf("expected ErrInvalidRowSize, got %v", err)
+	}
+	singular, _ := MatrixFromRows([][]byte{{1, 2}, {2, 4}})
+	if _, err := field.MatrixInvert(singular); err != ErrSingular {
+		t.Errorf("expected ErrSingular, got %v", err)
+	}
+
+	// The inverse of an invertible matrix undoes it.
+	m, _ := MatrixFromRows([][]byte{{1, 2, 3}, {0, 1, 4}, {5, 6, 0}})
+	inv, err := field.MatrixInvert(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, _ := Identity(3)
+	prod, _ := field.MatrixMultiply(m, inv)
+	for r := range prod {
+		if !equalBytes(prod[r], id[r]) {
+			t.Errorf("expected the identity, got %v", prod)
+			break
+		}
+	}
+}
+
+func TestGF_VandermondeMatrix(t *testing.T) {
+	type testrow struct {
+		field      *GF
+		rows, cols int
+	}
+	for _, row := range []testrow{
+		testrow{Poly210_g2, 4, 2},
+		testrow{Poly410_g2, 12, 8},
+		testrow{Poly84310_g3, 10, 5},
+		testrow{Default, 9, 6},
+	} {
+		v, err := row.field.VandermondeMatrix(row.rows, row.cols)
+		if err != nil {
+			t.Fatalf("%#v: unexpected error %v", row.field, err)
+		}
+		if v.At(0, 0) != 1 || v.At(2, 1) != 2 || v.Rows() != row.rows || v.Cols() != row.cols {
+			t.Errorf("%#v: unexpected matrix %v", row.field, v)
+		}
+		checkEverySubsetInvertible(t, row.field, v)
+
+		s, err := row.field.SystematicVandermondeMatrix(row.rows, row.cols)
+		if err != nil {
+			t.Fatalf("%#v: unexpected error %v", row.field, err)
+		}
+		id, _ := Identity(row.cols)
+		for r := 0; r < row.cols; r++ {
+			if !equalBytes(s[r], id[r]) {
+				t.Fatalf("%#v: expected the identity on top, got %v", row.field, s)
+			}
+		}
+		checkEverySubsetInvertible(t, row.field, s)
+	}
+
+	if _, err := Poly410_g2.VandermondeMatrix(17, 4); err != ErrInvalidRowSize {
+		t.Errorf("expected ErrInvalidRowSize, got %v", err)
+	}
+	if _, err := Default.SystematicVandermondeMatrix(3, 4); err != ErrInvalidRowSize {
+		t.Errorf("expected ErrInvalidRowSize, got %v", err)
+	}
+}
+
+func TestGF_CauchyMatrix(t *testing.T) {
+	for _, field := range []*GF{Poly310_g2, Poly410_g2, Default} {
+		k := 4
+		xs := []byte{4, 5, 6, 7}
+		ys := []byte{0, 1, 2, 3}
+		c, err := field.CauchyMatrix(xs, ys)
+		if err != nil {
+			t.Fatalf("%#v: unexpected error %v", field, err)
+		}
+		for i, x := range xs {
+			for j, y := range ys {
+				if field.Mul(c.At(i, j), field.Add(x, y)) != 1 {
+					t.Errorf("%#v: element (%d,%d) is not 1/(%d+%d)", field, i, j, x, y)
+				}
+			}
+		}
+		// Every square submatrix is invertible.
+		for size := 1; size <= k; size++ {
+			forEachSubset(k, size, func(rows []int) {
+				forEachSubset(k, size, func(cols []int) {
+					sub, _ := NewMatrix(size, size)
+					for i, r := range rows {
+						for j, col := range cols {
+							sub[i][j] = c[r][col]
+						}
+					}
+					if _, err := field.MatrixInvert(sub); err != nil {
+						t.Errorf("%#v: rows %v, cols %v: %v", field, rows, cols, err)
+					}
+				})
+			})
+		}
+		// So an identity stacked on top gives a systematic MDS matrix.
+		id, _ := Identity(k)
+		checkEverySubsetInvertible(t, field, append(id, c...))
+	}
+
+	for _, pts := range [][2][]byte{
+		{{1, 2}, {3, 1}},
+		{{1, 1}, {3, 4}},
+		{{1, 2}, {3, 8}},
+	} {
+		if _, err := Poly310_g2.CauchyMatrix(pts[0], pts[1]); err != ErrCauchyPoints {
+			t.Errorf("CauchyMatrix(%v, %v): expected ErrCauchyPoints, got %v", pts[0], pts[1], err)
+		}
+	}
+}
+
+// checkEverySubsetInvertible checks that every choice of m.Cols() rows of m
+// forms an invertible matrix.
+func checkEverySubsetInvertible(t *testing.T, field *GF, m Matrix) {
+	t.Helper()
+	forEachSubset(m.Rows(), m.Cols(), func(rows []int) {
+		var sub Matrix
+		for _, r := range rows {
+			sub = append(sub, m[r])
+		}
+		if _, err := field.MatrixInvert(sub); err != nil {
+			t.Errorf("%#v: rows %v: %v", field, rows, err)
+		}
+	})
+}
+
+func randomMatrix(prng *rand.Rand, field *GF, rows, cols int) Matrix {
+	m, _ := NewMatrix(rows, cols)
+	for _, row := range m {
+		for c := range row {
+			row[c] = byte(prng.Intn(int(field.Size())))
+		}
+	}
+	return m
+}
+
+// mulVector returns m*x.
+func mulVector(field *GF, m Matrix, x []byte) []byte {
+	out := make([]byte, m.Rows())
+	for r, row := range m {
+		for c, k := range row {
+			out[r] ^= field.Mul(k, x[c])
+		}
+	}
+	return out
+}
+
+func TestGF_RowReduce(t *testing.T) {
+	prng := rand.New(rand.NewSource(42))
+	for _, field := range []*GF{Poly210_g2, Poly410_g2, Default} {
+		for trial := 0; trial < 64; trial++ {
+			rows, cols, inner := 1+prng.Intn(7), 1+prng.Intn(7), 1+prng.Intn(7)
+			// A product through an inner dimension bounds the rank.
+			m, _ := field.MatrixMultiply(
+				randomMatrix(prng, field, rows, inner),
+				randomMatrix(prng, field, inner, cols))
+			before, _ := MatrixFromRows(m)
+			rref, pivots, err := field.RowReduce(m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for r := range m {
+				if !equalBytes(m[r], before[r]) {
+					t.Fatalf("RowReduce modified its argument")
+				}
+			}
+			rank, _ := field.Rank(m)
+			if rank != len(pivots) || rank > inner || rank > rows || rank > cols {
+				t.Errorf("%v: rank %d with pivots %v exceeds bounds", m, rank, pivots)
+			}
+			for i, c := range pivots {
+				if i > 0 && pivots[i-1] >= c {
+					t.Errorf("%v: pivots out of order: %v", m, pivots)
+				}
+				for r := range rref {
+					expect := byte(0)
+					if r == i {
+						expect = 1
+					}
+					if rref[r][c] != expect {
+						t.Errorf("%v: pivot column %d is not a unit vector in %v", m, c, rref)
+					}
+				}
+			}
+			for r := len(pivots); r < rows; r++ {
+				if !equalBytes(rref[r], make([]byte, cols)) {
+					t.Errorf("%v: expected zero row %d in %v", m, r, rref)
+				}
+			}
+
+			null, err := field.NullSpace(m)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if null.Rows()+rank != cols {
+				t.Errorf("%v: rank %d plus nullity %d is not %d", m, rank, null.Rows(), cols)
+			}
+			for _, v := range null {
+				if !equalBytes(mulVector(field, m, v), make([]byte, rows)) {
+					t.Errorf("%v: null vector %v does not map to zero", m, v)
+				}
+			}
+			if null.Rows() > 0 {
+				if r, _ := field.Rank(null); r != null.Rows() {
+					t.Errorf("%v: null space basis %v is not independent", m, null)
+				}
+			}
+		}
+	}
+}
+
+func TestGF_Determinant(t *testing.T) {
+	prng := rand.New(rand.NewSource(42))
+	field := Default
+	m, _ := MatrixFromRows([][]byte{{3, 5}, {7, 11}})
+	if det, _ := field.Determinant(m); det != field.Mul(3, 11)^field.Mul(5, 7) {
+		t.Errorf("expected ad+bc, got %d", det)
+	}
+	for trial := 0; trial < 64; trial++ {
+		n := 1 + prng.Intn(6)
+		a := randomMatrix(prng, field, n, n)
+		b := randomMatrix(prng, field, n, n)
+		ab, _ := field.MatrixMultiply(a, b)
+		da, _ := field.Determinant(a)
+		db, _ := field.Determinant(b)
+		dab, _ := field.Determinant(ab)
+		if dab != field.Mul(da, db) {
+			t.Errorf("det(AB)=%d, but det(A)det(B)=%d", dab, field.Mul(da, db))
+		}
+		_, err := field.MatrixInvert(a)
+		if (da == 0) != (err == ErrSingular) {
+			t.Errorf("%v: det %d, but MatrixInvert returned %v", a, da, err)
+		}
+	}
+	id, _ := Identity(5)
+	if det, _ := field.Determinant(id); det != 1 {
+		t.Errorf("expected det(I)=1, got %d", det)
+	}
+	wide, _ := NewMatrix(2, 3)
+	if _, err := field.Determinant(wide); err != ErrNotSquare {
+		t.Errorf("expected ErrNotSquare, got %v", err)
+	}
+}
+
+func TestGF_Solve(t *testing.T) {
+	prng := rand.New(rand.NewSource(42))
+	for _, field := range []*GF{Poly310_g2, Default} {
+		for trial := 0; trial < 64; trial++ {
+			rows, cols := 1+prng.Intn(6), 1+prng.Intn(6)
+			a := randomMatrix(prng, field, rows, cols)
+			if prng.Intn(2) == 0 && rows > 1 {
+				// Make the system rank deficient.
+				copy(a[rows-1], a[0])
+			}
+			x := make([]byte, cols)
+			for i := range x {
+				x[i] = byte(prng.Intn(int(field.Size())))
+			}
+			b := mulVector(field, a, x)
+			got, err := field.Solve(a, b)
+			if err != nil {
+				t.Fatalf("%v x = %v: unexpected error %v", a, b, err)
+			}
+			if !equalBytes(mulVector(field, a, got), b) {
+				t.Errorf("%v x = %v: got x = %v", a, b, got)
+			}
+		}
+	}
+
+	a, _ := MatrixFromRows([][]byte{{1, 2}, {2, 4}})
+	if _, err := Default.Solve(a, []byte{1, 1}); err != ErrNoSolution {
+		t.Errorf("expected ErrNoSolution, got %v", err)
+	}
+	if _, err := Default.Solve(a, []byte{1}); err != ErrMatrixSize {
+		t.Errorf("expected ErrMatrixSize, got %v", err)
+	}
+}
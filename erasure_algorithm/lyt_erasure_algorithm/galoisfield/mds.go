@@ -0,0 +1,153 @@
+package galoisfield
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// DefaultMDSSubsets is the number of row subsets that VerifyMDS tries when
+// MDSOptions.MaxSubsets is zero.
+const DefaultMDSSubsets = 1 << 14
+
+// maxMDSFailures bounds the erasure patterns that an MDSReport lists.
+const maxMDSFailures = 64
+
+// MDSOptions tunes VerifyMDS.  The zero value is ready to use.
+type MDSOptions struct {
+	// MaxSubsets bounds the work done.  If the matrix has at most this many
+	// row subsets, every one is checked; otherwise this many are sampled at
+	// random.  Zero means DefaultMDSSubsets.
+	MaxSubsets int
+
+	// Rand supplies the samples.  If nil, a source seeded from the current
+	// time is used.
+	Rand *rand.Rand
+}
+
+// MDSFailure is one erasure pattern that an encoding matrix cannot decode.
+type MDSFailure struct {
+	Rows   []int // the surviving rows, which form a singular matrix
+	Erased []int // the rows that were lost
+}
+
+// MDSReport is the result of VerifyMDS.
+type MDSReport struct {
+	Checked    int  // number of row subsets tried
+	Exhaustive bool // true iff Checked covers every row subset
+	Failed     int  // number of subsets that could not be inverted
+
+	// Failures lists the first few of the failed subsets, in the order
+	// they were tried.
+	Failures []MDSFailure
+}
+
+// OK returns true iff no tried subset failed.  Unless the report is
+// Exhaustive, that is evidence rather than proof that the matrix is MDS.
+func (r *MDSReport) OK() bool { return r.Failed == 0 }
+
+// VerifyMDS checks whether the encoding matrix m, with one row per shard and
+// one column per data shard, is maximum distance separable: whether every
+// choice of m.Cols() surviving rows forms an invertible matrix, so that any
+// m.Rows()-m.Cols() lost shards can be rebuilt.  opts may be nil.
+//
+// It returns an error if m is empty or ragged, or ErrInvalidRowSize if m has
+// fewer rows than columns.
+func (gf *GF) VerifyMDS(m Matrix, opts *MDSOptions) (*MDSReport, error) {
+	if err := m.Check(); err != nil {
+		return nil, err
+	}
+	n, k := m.Rows(), m.Cols()
+	if n < k {
+		return nil, ErrInvalidRowSize
+	}
+	var o MDSOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.MaxSubsets <= 0 {
+		o.MaxSubsets = DefaultMDSSubsets
+	}
+
+	report := new(MDSReport)
+	sub := make(Matrix, k)
+	check := func(rows []int) {
+		report.Checked++
+		for i, r := range rows {
+			sub[i] = m[r]
+		}
+		if _, err := gf.MatrixInvert(sub); err == nil {
+			return
+		}
+		report.Failed++
+		if len(report.Failures) < maxMDSFailures {
+			report.Failures = append(report.Failures, newMDSFailure(n, rows))
+		}
+	}
+
+	if binomial(n, k, o.MaxSubsets) <= o.MaxSubsets {
+		report.Exhaustive = true
+		forEachSubset(n, k, check)
+		return report, nil
+	}
+	prng := defaultPRNG(o.Rand)
+	rows := make([]int, k)
+	for i := 0; i < o.MaxSubsets; i++ {
+		copy(rows, prng.Perm(n)[:k])
+		sort.Ints(rows)
+		check(rows)
+	}
+	return report, nil
+}
+
+func newMDSFailure(n int, rows []int) MDSFailure {
+	f := MDSFailure{Rows: append([]int(nil), rows...)}
+	next := 0
+	for r := 0; r < n; r++ {
+		if next < len(rows) && rows[next] == r {
+			next++
+			continue
+		}
+		f.Erased = append(f.Erased, r)
+	}
+	return f
+}
+
+// binomial returns n choose k, or limit+1 if that is larger than limit.
+func binomial(n, k, limit int) int {
+	if n-k < k {
+		k = n - k
+	}
+	c := 1
+	for i := 1; i <= k; i++ {
+		// c is (n-k+i-1 choose i-1), so c*(n-k+i) is divisible by i.
+		// The values only grow, so stop as soon as the limit is passed.
+		c = c * (n - k + i) / i
+		if c > limit {
+			return limit + 1
+		}
+	}
+	return c
+}
+
+// forEachSubset calls f with every k-element subset of [0, n), in
+// lexicographic order.  f must not retain or modify its argument.
+func forEachSubset(n, k int, f func([]int)) {
+	subset := make([]int, k)
+	for i := range subset {
+		subset[i] = i
+	}
+	for {
+		f(subset)
+		i := k - 1
+		for i >= 0 && subset[i] == n-k+i {
+			i--
+		}
+		if i < 0 {
+			return
+		}
+		subset[i]++
+		for j := i + 1; j < k; j++ {
+			subset[j] = subset[j-1] + 1
+		}
+	}
+}
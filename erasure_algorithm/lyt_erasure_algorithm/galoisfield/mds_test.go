@@ -0,0 +1,137 @@
+package galoisfield
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestGF_VerifyMDS_Raid6(t *testing.T) {
+	prng := rand.New(rand.NewSource(42))
+	field := Poly84320_g2
+	// Losing two shards leaves (k+2 choose 2) patterns.  Check them all for
+	// small k, and a sample beyond that; each inversion costs O(k**3), so
+	// the sample shrinks as k grows to keep the test fast.  The exhaustive
+	// guarantee for every k comes from TestGF_Raid6EncoderMatrix_closedForm.
+	for k := 1; k <= 254; k++ {
+		opts := &MDSOptions{MaxSubsets: 256, Rand: prng}
+		if k > 64 {
+			opts.MaxSubsets = 8
+		}
+		if testing.Short() {
+			opts.MaxSubsets /= 4
+		}
+		m, err := field.Raid6EncoderMatrix(k+2, k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		report, err := field.VerifyMDS(m, opts)
+		if err != nil {
+			t.Fatalf("k=%d: unexpected error %v", k, err)
+		}
+		if !report.OK() {
+			t.Errorf("k=%d: %d patterns failed, e.g. %v", k, report.Failed, report.Failures[0])
+		}
+		if expect := (k + 2) * (k + 1) / 2; report.Exhaustive != (expect <= opts.MaxSubsets) {
+			t.Errorf("k=%d: expected exhaustive=%v, got %v", k, expect <= opts.MaxSubsets, report.Exhaustive)
+		} else if report.Exhaustive && report.Checked != expect {
+			t.Errorf("k=%d: expected %d subsets, got %d", k, expect, report.Checked)
+		}
+	}
+}
+
+// A systematic matrix with an all-ones P row is MDS against any two erasures
+// exactly when the Q coefficients are nonzero (data and P lost) and pairwise
+// distinct (two data shards lost, a 2x2 Vandermonde minor).  Checking that
+// for every k covers all (k+2 choose 2) patterns without inverting anything.
+func TestGF_Raid6EncoderMatrix_closedForm(t *testing.T) {
+	field := Poly84320_g2
+	for k := 1; k <= 254; k++ {
+		m, err := field.Raid6EncoderMatrix(k+2, k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		id, _ := Identity(k)
+		for r := range id {
+			if !equalBytes(m[r], id[r]) {
+				t.Fatalf("k=%d: data row %d is %v, expected %v", k, r, m[r], id[r])
+			}
+		}
+		var seen [256]bool
+		for c := 0; c < k; c++ {
+			if p := m[k][c]; p != 1 {
+				t.Fatalf("k=%d: P coefficient %d is %d, expected 1", k, c, p)
+			}
+			q := m[k+1][c]
+			if q == 0 {
+				t.Fatalf("k=%d: Q coefficient %d is zero", k, c)
+			}
+			if seen[q] {
+				t.Fatalf("k=%d: Q coefficient %d repeats %d", k, c, q)
+			}
+			seen[q] = true
+		}
+	}
+}
+
+func TestGF_VerifyMDS_failures(t *testing.T) {
+	// Rows 1 and 3 are equal, so any pattern that keeps both fails.
+	m, _ := MatrixFromRows([][]byte{
+		{1, 0},
+		{0, 1},
+		{1, 1},
+		{0, 1},
+	})
+	report, err := Default.VerifyMDS(m, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !report.Exhaustive || report.Checked != 6 || report.Failed != 1 || report.OK() {
+		t.Fatalf("expected 1 of 6 patterns to fail, got %+v", report)
+	}
+	f := report.Failures[0]
+	if len(f.Rows) != 2 || f.Rows[0] != 1 || f.Rows[1] != 3 ||
+		len(f.Erased) != 2 || f.Erased[0] != 0 || f.Erased[1] != 2 {
+		t.Errorf("expected rows [1 3] and erased [0 2], got %+v", f)
+	}
+
+	// A sample of a badly broken matrix finds failures too.
+	bad, _ := NewMatrix(40, 4)
+	for r := range bad {
+		bad[r][r%4] = 1
+	}
+	report, err = Default.VerifyMDS(bad, &MDSOptions{MaxSubsets: 100, Rand: rand.New(rand.NewSource(42))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Exhaustive || report.Checked != 100 || report.Failed == 0 || len(report.Failures) > maxMDSFailures {
+		t.Errorf("expected a sample of 100 with failures, got Checked=%d Exhaustive=%v Failed=%d",
+			report.Checked, report.Exhaustive, report.Failed)
+	}
+
+	wide, _ := NewMatrix(2, 3)
+	if _, err := Default.VerifyMDS(wide, nil); err != ErrInvalidRowSize {
+		t.Errorf("expected ErrInvalidRowSize, got %v", err)
+	}
+	if _, err := Default.VerifyMDS(nil, nil); err != ErrInvalidRowSize {
+		t.Errorf("expected ErrInvalidRowSize, got %v", err)
+	}
+}
+
+func TestBinomial(t *testing.T) {
+	type testrow struct {
+		n, k, limit, expect int
+	}
+	for _, row := range []testrow{
+		testrow{5, 0, 100, 1},
+		testrow{5, 5, 100, 1},
+		testrow{5, 2, 100, 10},
+		testrow{10, 5, 100, 101},
+		testrow{10, 5, 252, 252},
+		testrow{256, 254, 1 << 20, 32640},
+		testrow{256, 128, 1 << 20, 1<<20 + 1},
+	} {
+		if c := binomial(row.n, row.k, row.limit); c != row.expect {
+			t.Errorf("binomial(%d, %d, %d): expected %d, got %d", row.n, row.k, row.limit, row.expect, c)
+		}
+	}
+}
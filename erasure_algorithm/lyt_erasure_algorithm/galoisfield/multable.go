@@ -0,0 +1,60 @@
+package galoisfield
+
+import (
+	"sync"
+)
+
+// fullTables holds the optional 64 KiB multiplication table and its matching
+// inverse table.  They are built on first use, unless generatedTables already
+// supplied them, and are shared by every copy of a GF singleton.
+type fullTables struct {
+	once sync.Once
+	mul  *[256][256]byte
+	inv  *[256]byte
+}
+
+func (gf *GF) fullTables() *fullTables {
+	t := gf.tables
+	t.once.Do(func() {
+		if t.mul != nil {
+			return
+		}
+		mul := new([256][256]byte)
+		inv := new([256]byte)
+		n := gf.Size()
+		for x := uint(1); x < n; x++ {
+			for y := uint(1); y < n; y++ {
+				mul[x][y] = gf.Mul(byte(x), byte(y))
+			}
+			inv[x] = gf.Inv(byte(x))
+		}
+		t.mul, t.inv = mul, inv
+	})
+	return t
+}
+
+// MulTable returns the full multiplication table of the field, such that
+// MulTable()[x][y] == Mul(x, y).  Entries for values outside the field are 0.
+//
+// The table is built the first time it is requested and is shared by all
+// callers, so it MUST NOT be modified.
+func (gf *GF) MulTable() *[256][256]byte {
+	return gf.fullTables().mul
+}
+
+// MulRow returns the row of the multiplication table for c, such that
+// MulRow(c)[x] == Mul(c, x).  Hot loops that multiply many values by the same
+// coefficient can look the row up once and index it directly.
+//
+// The row is shared by all callers, so it MUST NOT be modified.
+func (gf *GF) MulRow(c byte) *[256]byte {
+	return &gf.fullTables().mul[c]
+}
+
+// InvTable returns the table of multiplicative inverses, such that
+// InvTable()[x] == Inv(x) for every nonzero x.  InvTable()[0] is 0.
+//
+// The table is shared by all callers, so it MUST NOT be modified.
+func (gf *GF) InvTable() *[256]byte {
+	return gf.fullTables().inv
+}
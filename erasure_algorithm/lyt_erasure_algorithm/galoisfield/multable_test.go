@@ -0,0 +1,55 @@
+package galoisfield
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestGF_MulTable(t *testing.T) {
+	for _, wk := range wellknown[1:] {
+		gf := wk.field
+		table := gf.MulTable()
+		inv := gf.InvTable()
+		for x := 0; x < 256; x++ {
+			row := gf.MulRow(byte(x))
+			if row != &table[x] {
+				t.Errorf("%s: expected MulRow(%d) to alias MulTable()[%[2]d]", wk.name, x)
+			}
+			for y := 0; y < 256; y++ {
+				var expect byte
+				if uint(x) < gf.Size() && uint(y) < gf.Size() {
+					expect = gf.Mul(byte(x), byte(y))
+				}
+				if table[x][y] != expect {
+					t.Errorf("%s: expected table[%d][%d]=%d, got %d", wk.name, x, y, expect, table[x][y])
+				}
+			}
+			var expect byte
+			if x != 0 && uint(x) < gf.Size() {
+				expect = gf.Inv(byte(x))
+			}
+			if inv[x] != expect {
+				t.Errorf("%s: expected inv[%d]=%d, got %d", wk.name, x, expect, inv[x])
+			}
+		}
+	}
+}
+
+func TestGF_MulTable_concurrent(t *testing.T) {
+	gf := New(256, 0x14d, 2)
+	tables := make([]*[256][256]byte, 8)
+	var wg sync.WaitGroup
+	for i := range tables {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			tables[i] = gf.MulTable()
+		}(i)
+	}
+	wg.Wait()
+	for i, table := range tables {
+		if table != tables[0] {
+			t.Errorf("[%d] expected every caller to share one table", i)
+		}
+	}
+}
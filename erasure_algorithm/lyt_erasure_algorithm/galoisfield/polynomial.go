@@ -31,7 +31,7 @@ func NewPolynomial(field *GF, coefficients ...byte) Polynomial {
 func (a Polynomial) Field() *GF { return a.field }
 
 // IsZero returns true iff this polynomial has no terms.
-func (a Polynomial) IsZero() bool { return a.coefficients == nil }
+func (a Polynomial) IsZero() bool { return len(a.coefficients) == 0 }
 
 // Degree returns the degree of this polynomial, with the convention that the
 // polynomial of zero terms has degree 0.
@@ -110,6 +110,148 @@ func (first Polynomial) Mul(rest ...Polynomial) Polynomial {
 	return NewPolynomial(first.field, prod...)
 }
 
+// DivMod divides a by b, returning the quotient q and remainder r such that
+// a == q*b + r and r has a lower degree than b (or is zero).  It returns
+// ErrDivByZero if b is zero, or ErrIncompatibleFields if a and b are drawn
+// from different Galois fields.
+func (a Polynomial) DivMod(b Polynomial) (q, r Polynomial, err error) {
+	if a.field != b.field {
+		return Polynomial{}, Polynomial{}, ErrIncompatibleFields
+	}
+	if b.IsZero() {
+		return Polynomial{}, Polynomial{}, ErrDivByZero
+	}
+	field := a.field
+	db := len(b.coefficients) - 1
+	if len(a.coefficients) <= db {
+		return NewPolynomial(field), a, nil
+	}
+	rem := expand(len(a.coefficients), a.coefficients)
+	quot := make([]byte, len(rem)-db)
+	inv := field.Inv(b.coefficients[db])
+	for i := len(rem) - 1; i >= db; i-- {
+		if rem[i] == 0 {
+			continue
+		}
+		k := field.Mul(rem[i], inv)
+		quot[i-db] = k
+		field.MulAddSlice(k, b.coefficients, rem[i-db:])
+	}
+	return NewPolynomial(field, quot...), NewPolynomial(field, rem[:db]...), nil
+}
+
+// Mod returns the remainder of dividing a by b.  See DivMod for details.
+func (a Polynomial) Mod(b Polynomial) (Polynomial, error) {
+	_, r, err := a.DivMod(b)
+	return r, err
+}
+
+// GCD returns the monic greatest common divisor g of a and b, together with
+// the Bézout coefficients s and t such that g == s*a + t*b, computed with the
+// extended Euclidean algorithm.  If both a and b are zero, so is g.  It
+// returns ErrIncompatibleFields if a and b are drawn from different Galois
+// fields.
+func (a Polynomial) GCD(b Polynomial) (g, s, t Polynomial, err error) {
+	if a.field != b.field {
+		return Polynomial{}, Polynomial{}, Polynomial{}, ErrIncompatibleFields
+	}
+	field := a.field
+	oldR, r := a, b
+	oldS, s := NewPolynomial(field, 1), NewPolynomial(field)
+	oldT, t := NewPolynomial(field), NewPolynomial(field, 1)
+	for !r.IsZero() {
+		q, rem, _ := oldR.DivMod(r)
+		oldR, r = r, rem
+		// Subtraction is the same as addition in GF(2**k).
+		oldS, s = s, oldS.Add(q.Mul(s))
+		oldT, t = t, oldT.Add(q.Mul(t))
+	}
+	if !oldR.IsZero() {
+		inv := field.Inv(oldR.coefficients[len(oldR.coefficients)-1])
+		oldR, oldS, oldT = oldR.Scale(inv), oldS.Scale(inv), oldT.Scale(inv)
+	}
+	return oldR, oldS, oldT, nil
+}
+
+// Derivative returns the formal derivative of this polynomial.  In GF(2**k),
+// n*x == x if n is odd and 0 if n is even, so only the odd-degree terms
+// survive.
+func (a Polynomial) Derivative() Polynomial {
+	if len(a.coefficients) < 2 {
+		return NewPolynomial(a.field)
+	}
+	coefficients := make([]byte, len(a.coefficients)-1)
+	for i := 1; i < len(a.coefficients); i += 2 {
+		coefficients[i-1] = a.coefficients[i]
+	}
+	return NewPolynomial(a.field, coefficients...)
+}
+
+// Compose returns the polynomial a(b(x)), or panics if a and b are drawn from
+// different Galois fields.
+func (a Polynomial) Compose(b Polynomial) Polynomial {
+	if a.field != b.field {
+		panic(ErrIncompatibleFields)
+	}
+	result := NewPolynomial(a.field)
+	for i := len(a.coefficients) - 1; i >= 0; i-- {
+		result = result.Mul(b).Add(NewPolynomial(a.field, a.coefficients[i]))
+	}
+	return result
+}
+
+// Pow returns this polynomial raised to the n'th power.  Pow(0) is 1, even
+// for the zero polynomial.
+func (a Polynomial) Pow(n uint) Polynomial {
+	result := NewPolynomial(a.field, 1)
+	base := a
+	for n > 0 {
+		if n&1 != 0 {
+			result = result.Mul(base)
+		}
+		n >>= 1
+		if n > 0 {
+			base = base.Mul(base)
+		}
+	}
+	return result
+}
+
+// Root is a root of a polynomial, together with its multiplicity.
+type Root struct {
+	Value        byte
+	Multiplicity uint
+}
+
+// Roots returns the roots of this polynomial in increasing order of value,
+// found by trying every element of the field.  Every element is a root of the
+// zero polynomial, for which Roots returns nil.
+func (a Polynomial) Roots() []Root {
+	if a.IsZero() {
+		return nil
+	}
+	var roots []Root
+	p := a
+	for x := uint(0); x < a.field.Size() && p.Degree() > 0; x++ {
+		if p.Evaluate(byte(x)) != 0 {
+			continue
+		}
+		// Subtraction is the same as addition in GF(2**k).
+		factor := NewPolynomial(a.field, byte(x), 1)
+		root := Root{Value: byte(x)}
+		for {
+			q, r, _ := p.DivMod(factor)
+			if !r.IsZero() {
+				break
+			}
+			p = q
+			root.Multiplicity++
+		}
+		roots = append(roots, root)
+	}
+	return roots
+}
+
 // GoString returns a Go-syntax representation of this polynomial.
 func (a Polynomial) GoString() string {
 	var buf bytes.Buffer
@@ -195,6 +337,54 @@ func (a Polynomial) Evaluate(x byte) byte {
 	return sum
 }
 
+// EvaluateMany substitutes each xs[i] for x and stores the result in out[i].
+// It uses Horner's rule with a row of the multiplication table per point, so
+// it needs one lookup per coefficient instead of Evaluate's two multiplies.
+// It panics with ErrSliceLength if out is shorter than xs.
+func (a Polynomial) EvaluateMany(xs, out []byte) {
+	if len(out) < len(xs) {
+		panic(ErrSliceLength)
+	}
+	for i, x := range xs {
+		row := a.field.MulRow(x)
+		var sum byte
+		for d := len(a.coefficients) - 1; d >= 0; d-- {
+			sum = row[sum] ^ a.coefficients[d]
+		}
+		out[i] = sum
+	}
+}
+
+// EvaluateAllNonzero returns the values of this polynomial at every nonzero
+// element of the field, in the order of the field's powers: the i'th value is
+// at g**i, i.e. Exp(i).  It works in the log domain, so it needs no
+// multiplication at all.
+func (a Polynomial) EvaluateAllNonzero() []byte {
+	field := a.field
+	out := make([]byte, field.m)
+	// a(g**i) is the sum of g**(log(k_j) + i*j) over the nonzero k_j; track
+	// each exponent as i advances.
+	type term struct{ e, step uint }
+	var terms []term
+	for j, k := range a.coefficients {
+		if k != 0 {
+			terms = append(terms, term{uint(field.log[k]), uint(j) % field.m})
+		}
+	}
+	for i := range out {
+		var sum byte
+		for t := range terms {
+			sum ^= field.exp[terms[t].e]
+			terms[t].e += terms[t].step
+			if terms[t].e >= field.m {
+				terms[t].e -= field.m
+			}
+		}
+		out[i] = sum
+	}
+	return out
+}
+
 func reduce(coefficients []byte) []byte {
 	for i := len(coefficients) - 1; i >= 0; i-- {
 		if coefficients[i] != 0 {
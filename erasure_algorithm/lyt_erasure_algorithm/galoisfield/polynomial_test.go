@@ -232,6 +232,238 @@ func TestPolynomial_Mul_incompatible(t *testing.T) {
 	}
 }
 
+func TestPolynomial_DivMod(t *testing.T) {
+	prng := rand.New(rand.NewSource(42))
+	for _, field := range []*GF{Poly410_g2, Poly84310_g3, Default} {
+		for trial := 0; trial < 512; trial++ {
+			a := randomPolynomial(prng, field, prng.Intn(12))
+			b := randomPolynomial(prng, field, prng.Intn(8))
+			if b.IsZero() {
+				continue
+			}
+			q, r, err := a.DivMod(b)
+			if err != nil {
+				t.Fatalf("(%v)/(%v): unexpected error %v", a, b, err)
+			}
+			if !q.Mul(b).Add(r).Equal(a) {
+				t.Errorf("expected (%v)*(%v)+(%v)=(%v)", q, b, r, a)
+			}
+			if !r.IsZero() && r.Degree() >= b.Degree() {
+				t.Errorf("(%v) mod (%v): remainder (%v) has degree >= divisor", a, b, r)
+			}
+			mod, err := a.Mod(b)
+			if err != nil || !mod.Equal(r) {
+				t.Errorf("(%v) mod (%v): expected (%v), got (%v), %v", a, b, r, mod, err)
+			}
+			// An exact multiple leaves no remainder.
+			q, r, _ = a.Mul(b).DivMod(b)
+			if !q.Equal(a) || !r.IsZero() {
+				t.Errorf("((%v)*(%v))/(%[2]v): expected (%[1]v) rem 0, got (%v) rem (%v)", a, b, q, r)
+			}
+		}
+	}
+
+	a := NewPolynomial(nil, 1, 2, 3)
+	if _, _, err := a.DivMod(NewPolynomial(nil)); err != ErrDivByZero {
+		t.Errorf("expected ErrDivByZero, got %v", err)
+	}
+	if _, _, err := a.DivMod(NewPolynomial(nil, 0, 0)); err != ErrDivByZero {
+		t.Errorf("expected ErrDivByZero, got %v", err)
+	}
+	if _, err := a.Mod(NewPolynomial(Poly84310_g3, 1)); err != ErrIncompatibleFields {
+		t.Errorf("expected ErrIncompatibleFields, got %v", err)
+	}
+}
+
+func TestPolynomial_GCD(t *testing.T) {
+	prng := rand.New(rand.NewSource(42))
+	field := Default
+	xPlus := func(c byte) Polynomial { return NewPolynomial(field, c, 1) }
+
+	g, _, _, err := xPlus(1).Mul(xPlus(2)).GCD(xPlus(1).Mul(xPlus(3)))
+	if err != nil || !g.Equal(xPlus(1)) {
+		t.Errorf("expected gcd=(%v), got (%v), %v", xPlus(1), g, err)
+	}
+
+	for trial := 0; trial < 256; trial++ {
+		common := randomPolynomial(prng, field, prng.Intn(4))
+		a := randomPolynomial(prng, field, prng.Intn(6)).Mul(common)
+		b := randomPolynomial(prng, field, prng.Intn(6)).Mul(common)
+		g, s, u, err := a.GCD(b)
+		if err != nil {
+			t.Fatalf("gcd(%v, %v): unexpected error %v", a, b, err)
+		}
+		if !s.Mul(a).Add(u.Mul(b)).Equal(g) {
+			t.Errorf("gcd(%v, %v): expected (%v)*a+(%v)*b=(%v)", a, b, s, u, g)
+		}
+		if a.IsZero() && b.IsZero() {
+			if !g.IsZero() {
+				t.Errorf("gcd(0, 0): expected 0, got (%v)", g)
+			}
+			continue
+		}
+		if lead := g.Coefficient(g.Degree()); lead != 1 {
+			t.Errorf("gcd(%v, %v): expected a monic result, got (%v)", a, b, g)
+		}
+		for _, p := range []Polynomial{a, b} {
+			if r, _ := p.Mod(g); !r.IsZero() {
+				t.Errorf("gcd(%v, %v)=(%v) does not divide (%v)", a, b, g, p)
+			}
+		}
+		if r, _ := g.Mod(common); !common.IsZero() && !r.IsZero() {
+			t.Errorf("gcd(%v, %v)=(%v) is not a multiple of (%v)", a, b, g, common)
+		}
+	}
+
+	if _, _, _, err := NewPolynomial(nil, 1).GCD(NewPolynomial(Poly84310_g3, 1)); err != ErrIncompatibleFields {
+		t.Errorf("expected ErrIncompatibleFields, got %v", err)
+	}
+}
+
+func TestPolynomial_Derivative(t *testing.T) {
+	// d/dx (x^4 + 3x^3 + 5x^2 + 7x + 9) = 3x^2 + 7, since 2 == 4 == 0.
+	p := NewPolynomial(nil, 9, 7, 5, 3, 1)
+	if d := p.Derivative(); !d.Equal(NewPolynomial(nil, 7, 0, 3)) {
+		t.Errorf("expected 3x^2 + 7, got %v", d)
+	}
+	if d := NewPolynomial(nil, 9).Derivative(); !d.IsZero() {
+		t.Errorf("expected 0, got %v", d)
+	}
+
+	// The product rule holds for formal derivatives.
+	prng := rand.New(rand.NewSource(42))
+	for _, field := range []*GF{Poly210_g2, Poly410_g2, Default} {
+		for trial := 0; trial < 64; trial++ {
+			a := randomPolynomial(prng, field, prng.Intn(8))
+			b := randomPolynomial(prng, field, prng.Intn(8))
+			lhs := a.Mul(b).Derivative()
+			rhs := a.Derivative().Mul(b).Add(a.Mul(b.Derivative()))
+			if !lhs.Equal(rhs) {
+				t.Errorf("((%v)*(%v))': expected (%v), got (%v)", a, b, rhs, lhs)
+			}
+		}
+	}
+}
+
+func TestPolynomial_Compose(t *testing.T) {
+	prng := rand.New(rand.NewSource(42))
+	for _, field := range []*GF{Poly210_g2, Poly610_g7, Default} {
+		for trial := 0; trial < 64; trial++ {
+			a := randomPolynomial(prng, field, prng.Intn(6))
+			b := randomPolynomial(prng, field, prng.Intn(4))
+			c := a.Compose(b)
+			for x := uint(0); x < field.Size(); x++ {
+				if y, expect := c.Evaluate(byte(x)), a.Evaluate(b.Evaluate(byte(x))); y != expect {
+					t.Fatalf("(%v)∘(%v) at %d: expected %d, got %d", a, b, x, expect, y)
+				}
+			}
+		}
+	}
+	e := panicValue(func() {
+		_ = NewPolynomial(Poly210_g2, 1).Compose(NewPolynomial(Poly310_g2, 1))
+	})
+	if e != ErrIncompatibleFields {
+		t.Errorf("expected ErrIncompatibleFields, got %v", e)
+	}
+}
+
+func TestPolynomial_Pow(t *testing.T) {
+	prng := rand.New(rand.NewSource(42))
+	for _, field := range []*GF{Poly310_g2, Default} {
+		for trial := 0; trial < 32; trial++ {
+			a := randomPolynomial(prng, field, prng.Intn(4))
+			expect := NewPolynomial(field, 1)
+			for n := uint(0); n < 10; n++ {
+				if p := a.Pow(n); !p.Equal(expect) {
+					t.Errorf("(%v)^%d: expected (%v), got (%v)", a, n, expect, p)
+				}
+				expect = expect.Mul(a)
+			}
+		}
+	}
+}
+
+func TestPolynomial_Roots(t *testing.T) {
+	prng := rand.New(rand.NewSource(42))
+	for _, field := range []*GF{Poly210_g2, Poly410_g2, Poly710_g2, Default} {
+		for trial := 0; trial < 32; trial++ {
+			// Build a polynomial from known roots and a random leading
+			// coefficient.
+			expect := make(map[byte]uint)
+			p := NewPolynomial(field, byte(1+prng.Intn(int(field.Size())-1)))
+			for i := prng.Intn(6); i > 0; i-- {
+				r := byte(prng.Intn(int(field.Size())))
+				expect[r]++
+				p = p.Mul(NewPolynomial(field, r, 1))
+			}
+			roots := p.Roots()
+			if len(roots) != len(expect) {
+				t.Fatalf("(%v): expected %d roots, got %v", p, len(expect), roots)
+			}
+			for i, root := range roots {
+				if i > 0 && roots[i-1].Value >= root.Value {
+					t.Errorf("(%v): roots out of order: %v", p, roots)
+				}
+				if expect[root.Value] != root.Multiplicity {
+					t.Errorf("(%v): expected root %d with multiplicity %d, got %d", p, root.Value, expect[root.Value], root.Multiplicity)
+				}
+			}
+		}
+	}
+
+	// x^2 + x + 1 is irreducible over GF(8).
+	if roots := NewPolynomial(Poly310_g2, 1, 1, 1).Roots(); roots != nil {
+		t.Errorf("expected no roots, got %v", roots)
+	}
+	if roots := NewPolynomial(nil).Roots(); roots != nil {
+		t.Errorf("expected no roots for the zero polynomial, got %v", roots)
+	}
+}
+
+func TestPolynomial_EvaluateMany(t *testing.T) {
+	prng := rand.New(rand.NewSource(42))
+	for _, field := range []*GF{Poly210_g2, Poly610_g7, Poly84310_g3, Default} {
+		xs := make([]byte, field.Size())
+		for x := range xs {
+			xs[x] = byte(x)
+		}
+		out := make([]byte, len(xs))
+		for trial := 0; trial < 32; trial++ {
+			p := randomPolynomial(prng, field, prng.Intn(20))
+			p.EvaluateMany(xs, out)
+			for _, x := range xs {
+				if expect := p.Evaluate(x); out[x] != expect {
+					t.Errorf("(%v) at %d: expected %d, got %d", p, x, expect, out[x])
+				}
+			}
+			all := p.EvaluateAllNonzero()
+			if uint(len(all)) != field.Size()-1 {
+				t.Fatalf("%#v: expected %d values, got %d", field, field.Size()-1, len(all))
+			}
+			for i, y := range all {
+				if expect := p.Evaluate(field.Exp(byte(i))); y != expect {
+					t.Errorf("(%v) at g**%d: expected %d, got %d", p, i, expect, y)
+				}
+			}
+		}
+	}
+
+	e := panicValue(func() { NewPolynomial(nil, 1).EvaluateMany(make([]byte, 3), make([]byte, 2)) })
+	if e != ErrSliceLength {
+		t.Errorf("expected ErrSliceLength, got %v", e)
+	}
+}
+
+// randomPolynomial returns a polynomial of degree at most deg with random
+// coefficients.
+func randomPolynomial(prng *rand.Rand, field *GF, deg int) Polynomial {
+	coefficients := make([]byte, deg+1)
+	for i := range coefficients {
+		coefficients[i] = byte(prng.Intn(int(field.Size())))
+	}
+	return NewPolynomial(field, coefficients...)
+}
+
 func checkCompareAxioms(t *testing.T, a, b Polynomial, cmp int, lt, gt, eq, qe bool) {
 	if eq != qe {
 		t.Errorf("equality not commutative for %#v and %#v", a, b)
@@ -523,3 +755,37 @@ func equalBytes(a, b []byte) bool {
 	}
 	return true
 }
+
+func benchmarkPolynomialEvaluate(b *testing.B, evaluate func(p Polynomial, xs, out []byte)) {
+	prng := rand.New(rand.NewSource(42))
+	p := randomPolynomial(prng, Default, 31)
+	xs := make([]byte, 255)
+	for i := range xs {
+		xs[i] = Default.Exp(byte(i))
+	}
+	out := make([]byte, len(xs))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		evaluate(p, xs, out)
+	}
+}
+
+func BenchmarkPolynomial_Evaluate_255(b *testing.B) {
+	benchmarkPolynomialEvaluate(b, func(p Polynomial, xs, out []byte) {
+		for i, x := range xs {
+			out[i] = p.Evaluate(x)
+		}
+	})
+}
+
+func BenchmarkPolynomial_EvaluateMany_255(b *testing.B) {
+	benchmarkPolynomialEvaluate(b, func(p Polynomial, xs, out []byte) {
+		p.EvaluateMany(xs, out)
+	})
+}
+
+func BenchmarkPolynomial_EvaluateAllNonzero_255(b *testing.B) {
+	benchmarkPolynomialEvaluate(b, func(p Polynomial, xs, out []byte) {
+		copy(out, p.EvaluateAllNonzero())
+	})
+}
@@ -0,0 +1,30 @@
+package galoisfield
+
+// QR codes use Reed-Solomon over Poly84320_g2 with the generator polynomial
+// (x - 2**0) (x - 2**1) ... (x - 2**(ecc-1)), which is exactly the code
+// implemented by RSCodec.  Each block is its data codewords followed by its
+// error-correction codewords, and a block never exceeds 255 codewords.
+
+// QRErrorCorrection returns the eccLen error-correction codewords for a QR
+// code data block, as specified by ISO/IEC 18004.  It returns ErrCodeLength
+// unless data and eccLen are both non-empty and fit in a 255-codeword block.
+func QRErrorCorrection(data []byte, eccLen int) ([]byte, error) {
+	rs, err := NewRSCodec(Poly84320_g2, len(data)+eccLen, len(data))
+	if err != nil {
+		return nil, err
+	}
+	codeword, _ := rs.Encode(data)
+	return codeword[len(data):], nil
+}
+
+// QRCorrect corrects a QR code block, its data codewords followed by eccLen
+// error-correction codewords, in place and returns the number of codewords it
+// changed.  erasures lists the indices of codewords known to be unreadable.
+// See RSCodec.Decode for the error cases.
+func QRCorrect(block []byte, eccLen int, erasures []int) (int, error) {
+	rs, err := NewRSCodec(Poly84320_g2, len(block), len(block)-eccLen)
+	if err != nil {
+		return 0, err
+	}
+	return rs.Decode(block, erasures)
+}
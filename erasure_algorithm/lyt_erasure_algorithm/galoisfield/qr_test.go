@@ -0,0 +1,64 @@
+package galoisfield
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestQRErrorCorrection(t *testing.T) {
+	type testrow struct {
+		name string
+		data []byte
+		ecc  []byte
+	}
+	for _, row := range []testrow{
+		// "HELLO WORLD" as a version 1-M symbol.
+		testrow{
+			"HELLO WORLD",
+			[]byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17},
+			[]byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23},
+		},
+		// "01234567" as a version 1-M symbol, from ISO/IEC 18004 Annex I.
+		testrow{
+			"01234567",
+			[]byte{
+				0x10, 0x20, 0x0c, 0x56, 0x61, 0x80, 0xec, 0x11,
+				0xec, 0x11, 0xec, 0x11, 0xec, 0x11, 0xec, 0x11,
+			},
+			[]byte{0xa5, 0x24, 0xd4, 0xc1, 0xed, 0x36, 0xc7, 0x87, 0x2c, 0x55},
+		},
+	} {
+		ecc, err := QRErrorCorrection(row.data, len(row.ecc))
+		if err != nil {
+			t.Fatalf("%s: unexpected error %v", row.name, err)
+		}
+		if !bytes.Equal(ecc, row.ecc) {
+			t.Errorf("%s: expected %v, got %v", row.name, row.ecc, ecc)
+		}
+
+		block := append(append([]byte(nil), row.data...), row.ecc...)
+		block[0] ^= 0xff
+		block[7] ^= 0x01
+		block[20] = 0
+		block[24] = 0
+		changed, err := QRCorrect(block, len(row.ecc), []int{20, 24})
+		if err != nil {
+			t.Fatalf("%s: unexpected error %v", row.name, err)
+		}
+		if changed != 4 || !bytes.Equal(block[:len(row.data)], row.data) || !bytes.Equal(block[len(row.data):], row.ecc) {
+			t.Errorf("%s: expected 4 corrections, got %d: %v", row.name, changed, block)
+		}
+	}
+}
+
+func TestQRErrorCorrection_errors(t *testing.T) {
+	if _, err := QRErrorCorrection(nil, 10); err != ErrCodeLength {
+		t.Errorf("expected ErrCodeLength, got %v", err)
+	}
+	if _, err := QRErrorCorrection(make([]byte, 200), 60); err != ErrCodeLength {
+		t.Errorf("expected ErrCodeLength, got %v", err)
+	}
+	if _, err := QRCorrect(make([]byte, 10), 10, nil); err != ErrCodeLength {
+		t.Errorf("expected ErrCodeLength, got %v", err)
+	}
+}
@@ -2,7 +2,6 @@ package galoisfield
 
 import (
 	"errors"
-	"fmt"
 	"io"
 )
 
@@ -30,11 +29,42 @@ type Raid6 struct {
 	DataShards   int //
 	ParityShards int // 2 Number of parity shards, should not be modified.
 	Shards       int // Total number of shards. It should be DataShards + 1
-	m            matrix
+	m            Matrix
 	field        *GF
+	cache        *decodeCache
+
+	// Stripes of more than 256 shards use m16 and field16 instead of m and
+	// field, and need shards of an even length.
+	m16     Matrix65536
+	field16 *GF65536
 }
 
+// MaxRaid6DataShards is the most data shards that Raid6New accepts.  Wide
+// stripes keep dense matrices over GF(65536), so the encoding matrix takes
+// about 2*d*d bytes for d data shards, and each reconstruction that must
+// invert a matrix needs up to 8*d*d more bytes and O(d**3) time.  This limit
+// keeps those to a few megabytes.
+const MaxRaid6DataShards = 1024
+
+// Raid6New returns a RAID6 encoder that caches up to DefaultDecodeCacheSize
+// bytes of decode matrices, on top of the memory described for
+// MaxRaid6DataShards.  Stripes of up to 256 shards are coded over GF(256); wider
+// ones, of up to MaxRaid6DataShards data shards, are coded over GF(65536),
+// and every shard must then have an even length.
 func Raid6New(dataShards, parityShards int) (Encoder, error) {
+	r, err := Raid6NewWithCacheSize(dataShards, parityShards, DefaultDecodeCacheSize)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Raid6NewWithCacheSize is like Raid6New, but caches up to cacheSize bytes of
+// decode matrices, one per pattern of missing shards, each of the size given
+// for DefaultDecodeCacheSize.  A cacheSize of zero or less
+// disables the cache, so that every reconstruction inverts a matrix.  It
+// returns the concrete *Raid6, so that callers can reach CacheStats.
+func Raid6NewWithCacheSize(dataShards, parityShards, cacheSize int) (*Raid6, error) {
 	r := Raid6{
 		DataShards:   dataShards,
 		ParityShards: parityShards,
@@ -47,7 +77,7 @@ func Raid6New(dataShards, parityShards int) (Encoder, error) {
 	if dataShards <= 0 || parityShards < 0 {
 		return nil, ErrInvShardNum
 	}
-	if dataShards+parityShards > 256 {
+	if dataShards > MaxRaid6DataShards {
 		return nil, ErrMaxShardNum
 	}
 
@@ -55,103 +85,229 @@ func Raid6New(dataShards, parityShards int) (Encoder, error) {
 		return &r, nil
 	}
 
-	r.field = Poly84320_g2
-	r.m, _ = r.field.Raid6EncoderMatrix(r.Shards, r.DataShards)
+	if r.Shards <= 256 {
+		r.field = Poly84320_g2
+		r.m, _ = r.field.Raid6EncoderMatrix(r.Shards, r.DataShards)
+	} else {
+		r.field16 = Poly1612310_g2
+		r.m16, _ = r.field16.Raid6EncoderMatrix(r.Shards, r.DataShards)
+	}
+	if cacheSize > 0 {
+		r.cache = newDecodeCache(cacheSize)
+	}
 
 	return &r, nil
 }
 
 // An array 'shards' containing data shards followed by parity shards.
+// The parity shards must already be allocated; they are overwritten in place.
 func (r *Raid6) Encode(shards [][]byte) error {
-	fmt.Println("encoderShards:", shards)
 	if len(shards) != r.Shards {
 		return ErrShardNoData
 	}
-	if len(shards[0]) != len(shards[r.DataShards]) {
-		return ErrShardSize
+	size, err := shardSize(shards)
+	if err != nil {
+		return err
+	}
+	if size == 0 {
+		return ErrShardNoData
+	}
+	for _, shard := range shards {
+		if len(shard) != size {
+			return ErrShardSize
+		}
+	}
+	if r.field16 != nil && size%2 != 0 {
+		return ErrOddLength
+	}
+	m := r.encoderMatrix()
+	for i := r.DataShards; i < r.Shards; i++ {
+		r.codeShard(m, i, shards[:r.DataShards], shards[i])
 	}
-	data := shards[0:r.DataShards]
-	encoderResult, _ := r.field.MatrixMultiply(r.m, data)
-	fmt.Println("encoderResult:", encoderResult)
-	copy(shards, encoderResult)
-
 	return nil
 }
 
+// ReconstructData recreates the missing data shards only.  Missing parity
+// shards are left as they are.
 func (r *Raid6) ReconstructData(shards [][]byte) error {
+	return r.reconstruct(shards, true)
+}
+
+// Reconstruct recreates all missing shards.  A shard is missing if it has
+// zero length; if its capacity is large enough it is reused, otherwise a new
+// slice is allocated.
+func (r *Raid6) Reconstruct(shards [][]byte) error {
+	return r.reconstruct(shards, false)
+}
+
+func (r *Raid6) reconstruct(shards [][]byte, dataOnly bool) error {
 	if len(shards) != r.Shards {
 		return ErrTooFewShards
 	}
+	size, err := shardSize(shards)
+	if err != nil {
+		return err
+	}
+	if r.field16 != nil && size%2 != 0 {
+		return ErrOddLength
+	}
+	present := 0
+	for _, shard := range shards {
+		if len(shard) != 0 {
+			present++
+		}
+	}
+	if present == r.Shards {
+		return nil
+	}
+	if present < r.DataShards {
+		return ErrTooFewShards
+	}
+
 	subShards := make([][]byte, r.DataShards)
 	validIndices := make([]int, r.DataShards)
-	invalidIndices := make([]int, 0)
 	subMatrixRow := 0
 	for matrixRow := 0; matrixRow < r.Shards && subMatrixRow < r.DataShards; matrixRow++ {
 		if len(shards[matrixRow]) != 0 {
 			subShards[subMatrixRow] = shards[matrixRow]
 			validIndices[subMatrixRow] = matrixRow
 			subMatrixRow++
-		} else {
-			invalidIndices = append(invalidIndices, matrixRow)
 		}
 	}
 
-	subMatrix, _ := newMatrix(r.DataShards, r.DataShards)
-	for subMatrixRow, validIndex := range validIndices {
-		for c := 0; c < r.DataShards; c++ {
-			subMatrix[subMatrixRow][c] = r.m[validIndex][c]
+	// Recreate the missing data shards from the valid ones.  If only parity
+	// is missing, there is nothing to decode.
+	if validIndices[r.DataShards-1] != r.DataShards-1 {
+		decodeMatrix, err := r.decodeMatrix(validIndices)
+		if err != nil {
+			return err
+		}
+		for i := 0; i < r.DataShards; i++ {
+			if len(shards[i]) == 0 {
+				shards[i] = allocShard(shards[i], size)
+				r.codeShard(decodeMatrix, i, subShards, shards[i])
+			}
+		}
+	}
+	if dataOnly {
+		return nil
+	}
+
+	// With every data shard intact, the missing parity is a plain encode.
+	m := r.encoderMatrix()
+	for i := r.DataShards; i < r.Shards; i++ {
+		if len(shards[i]) == 0 {
+			shards[i] = allocShard(shards[i], size)
+			r.codeShard(m, i, shards[:r.DataShards], shards[i])
 		}
 	}
-	dataDecodeMatrix, err := r.field.MatrixInvert(subMatrix)
+	return nil
+}
+
+// decodeMatrix returns the inverse of the rows of the encoding matrix at
+// validIndices, as a Matrix or a Matrix65536 like encoderMatrix, from the cache
+// if possible.  The result MUST NOT be modified.
+func (r *Raid6) decodeMatrix(validIndices []int) (interface{}, error) {
+	var key shardBitmap
+	if r.cache != nil {
+		key = newShardBitmap(validIndices)
+		if m := r.cache.get(key); m != nil {
+			return m, nil
+		}
+	}
+	var m interface{}
+	var err error
+	size := r.DataShards * r.DataShards
+	if r.field16 != nil {
+		m, err = r.invertRows16(validIndices)
+		size *= 2
+	} else {
+		m, err = r.invertRows(validIndices)
+	}
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if r.cache != nil {
+		r.cache.put(key, m, size)
 	}
-	// reconstructData, err := r.field.MatrixMultiply(dataDecodeMatrix, subShards)
-	// copy(shards, reconstructData)
-	// fmt.Println("DecoderResult data:", shards)
+	return m, nil
+}
 
-	reconstructData, err := r.field.MatrixMultiply(dataDecodeMatrix, subShards)
-	data := reconstructData[0:r.DataShards]
-	encoderResult, _ := r.field.MatrixMultiply(r.m, data)
-	copy(shards, encoderResult)
-	fmt.Println("DecoderResult:", shards)
-	return err
+func (r *Raid6) invertRows(validIndices []int) (Matrix, error) {
+	subMatrix, _ := NewMatrix(r.DataShards, r.DataShards)
+	for subMatrixRow, validIndex := range validIndices {
+		copy(subMatrix[subMatrixRow], r.m[validIndex][:r.DataShards])
+	}
+	return r.field.MatrixInvert(subMatrix)
 }
 
-func (r *Raid6) Reconstruct(shards [][]byte) error {
-	if len(shards) != r.Shards {
-		return ErrTooFewShards
+func (r *Raid6) invertRows16(validIndices []int) (Matrix65536, error) {
+	subMatrix, _ := NewMatrix65536(r.DataShards, r.DataShards)
+	for subMatrixRow, validIndex := range validIndices {
+		copy(subMatrix[subMatrixRow], r.m16[validIndex][:r.DataShards])
 	}
-	subShards := make([][]byte, r.DataShards)
-	validIndices := make([]int, r.DataShards)
-	invalidIndices := make([]int, 0)
-	subMatrixRow := 0
-	for matrixRow := 0; matrixRow < r.Shards && subMatrixRow < r.DataShards; matrixRow++ {
-		if len(shards[matrixRow]) != 0 {
-			subShards[subMatrixRow] = shards[matrixRow]
-			validIndices[subMatrixRow] = matrixRow
-			subMatrixRow++
-		} else {
-			invalidIndices = append(invalidIndices, matrixRow)
+	return r.field16.MatrixInvert(subMatrix)
+}
+
+// CacheStats reports the activity of the decode-matrix cache.  It returns the
+// zero CacheStats if the cache is disabled.
+func (r *Raid6) CacheStats() CacheStats {
+	if r.cache == nil {
+		return CacheStats{}
+	}
+	return r.cache.stats()
+}
+
+// encoderMatrix returns the encoding matrix: r.m16 for a wide stripe, else
+// r.m.
+func (r *Raid6) encoderMatrix() interface{} {
+	if r.field16 != nil {
+		return r.m16
+	}
+	return r.m
+}
+
+// codeShard sets out to the product of row i of m, which is a Matrix or a
+// Matrix65536 as returned by encoderMatrix, and inputs.
+func (r *Raid6) codeShard(m interface{}, i int, inputs [][]byte, out []byte) {
+	switch m := m.(type) {
+	case Matrix:
+		row := m[i]
+		r.field.MulSlice(row[0], inputs[0], out)
+		for j := 1; j < len(inputs); j++ {
+			r.field.MulAddSlice(row[j], inputs[j], out)
+		}
+	case Matrix65536:
+		row := m[i]
+		r.field16.MulSlice(row[0], inputs[0], out)
+		for j := 1; j < len(inputs); j++ {
+			r.field16.MulAddSlice(row[j], inputs[j], out)
 		}
 	}
+}
 
-	subMatrix, _ := newMatrix(r.DataShards, r.DataShards)
-	for subMatrixRow, validIndex := range validIndices {
-		for c := 0; c < r.DataShards; c++ {
-			subMatrix[subMatrixRow][c] = r.m[validIndex][c]
+// shardSize returns the common length of the non-empty shards.
+func shardSize(shards [][]byte) (int, error) {
+	size := 0
+	for _, shard := range shards {
+		if len(shard) == 0 {
+			continue
+		}
+		if size == 0 {
+			size = len(shard)
+		} else if len(shard) != size {
+			return 0, ErrShardSize
 		}
 	}
-	dataDecodeMatrix, err := r.field.MatrixInvert(subMatrix)
-	if err != nil {
-		return err
+	return size, nil
+}
+
+// allocShard returns shard resized to size, reusing its storage if possible.
+func allocShard(shard []byte, size int) []byte {
+	if cap(shard) >= size {
+		return shard[:size]
 	}
-	reconstructData, err := r.field.MatrixMultiply(dataDecodeMatrix, subShards)
-	data := reconstructData[0:r.DataShards]
-	encoderResult, _ := r.field.MatrixMultiply(r.m, data)
-	copy(shards, encoderResult)
-	fmt.Println("DecoderResult:", shards)
-	return err
+	return make([]byte, size)
 }
 
 func (r *Raid6) Split(data []byte) ([][]byte, error) {
@@ -161,6 +317,9 @@ func (r *Raid6) Split(data []byte) ([][]byte, error) {
 	dataLen := len(data)
 	// Calculate number of bytes per data shard.
 	perShard := (len(data) + r.DataShards - 1) / r.DataShards
+	if r.field16 != nil {
+		perShard += perShard % 2
+	}
 
 	if cap(data) > len(data) {
 		data = data[:cap(data)]
@@ -175,7 +334,7 @@ func (r *Raid6) Split(data []byte) ([][]byte, error) {
 		copy(padding, data[perShard*fullShards:])
 		data = data[0 : perShard*fullShards]
 	} else {
-		for i := dataLen; i < dataLen+r.DataShards; i++ {
+		for i := dataLen; i < r.DataShards*perShard; i++ {
 			data[i] = 0
 		}
 	}
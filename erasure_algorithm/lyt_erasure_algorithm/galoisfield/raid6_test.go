@@ -40,3 +40,136 @@ func TestRaid6(t *testing.T) {
 	}
 	print(split[1])
 }
+
+func TestRaid6_Reconstruct(t *testing.T) {
+	var prng = rand.New(rand.NewSource(42))
+	for _, dataShards := range []int{1, 3, 10} {
+		enc, err := Raid6New(dataShards, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		shards := make([][]byte, dataShards+2)
+		for i := range shards {
+			shards[i] = make([]byte, 67)
+		}
+		for _, shard := range shards[:dataShards] {
+			prng.Read(shard)
+		}
+		if err := enc.Encode(shards); err != nil {
+			t.Fatal(err)
+		}
+		for a := range shards {
+			for b := a; b < len(shards); b++ {
+				damaged := make([][]byte, len(shards))
+				copy(damaged, shards)
+				damaged[a] = nil
+				damaged[b] = nil
+				if err := enc.Reconstruct(damaged); err != nil {
+					t.Fatalf("[d=%d] lost %d,%d: %v", dataShards, a, b, err)
+				}
+				for i := range shards {
+					if !equalBytes(damaged[i], shards[i]) {
+						t.Errorf("[d=%d] lost %d,%d: shard %d expected %v, got %v",
+							dataShards, a, b, i, shards[i], damaged[i])
+					}
+				}
+
+				copy(damaged, shards)
+				damaged[a] = nil
+				damaged[b] = nil
+				if err := enc.ReconstructData(damaged); err != nil {
+					t.Fatalf("[d=%d] lost %d,%d: %v", dataShards, a, b, err)
+				}
+				for i := range shards[:dataShards] {
+					if !equalBytes(damaged[i], shards[i]) {
+						t.Errorf("[d=%d] lost %d,%d: shard %d expected %v, got %v",
+							dataShards, a, b, i, shards[i], damaged[i])
+					}
+				}
+			}
+		}
+
+		damaged := make([][]byte, len(shards))
+		copy(damaged, shards)
+		damaged[0], damaged[1], damaged[2] = nil, nil, nil
+		if err := enc.Reconstruct(damaged); err != ErrTooFewShards {
+			t.Errorf("[d=%d] expected ErrTooFewShards, got %v", dataShards, err)
+		}
+	}
+}
+
+func benchmarkRaid6Encode(b *testing.B, dataShards, shardSize int) {
+	enc, err := Raid6New(dataShards, 2)
+	if err != nil {
+		b.Fatal(err)
+	}
+	shards := make([][]byte, dataShards+2)
+	for i := range shards {
+		shards[i] = make([]byte, shardSize)
+	}
+	for _, shard := range shards[:dataShards] {
+		rand.New(rand.NewSource(42)).Read(shard)
+	}
+	b.SetBytes(int64(dataShards * shardSize))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := enc.Encode(shards); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkRaid6_Encode_3x64K(b *testing.B) { benchmarkRaid6Encode(b, 3, 1<<16) }
+func BenchmarkRaid6_Encode_10x1M(b *testing.B) { benchmarkRaid6Encode(b, 10, 1<<20) }
+
+func TestRaid6_Reconstruct_wide(t *testing.T) {
+	var prng = rand.New(rand.NewSource(42))
+	const dataShards = 300
+	enc, err := Raid6New(dataShards, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shards, err := enc.Split(make([]byte, dataShards*33))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shards[0]) != 34 {
+		t.Fatalf("expected shards of an even length 34, got %d", len(shards[0]))
+	}
+	for _, shard := range shards[:dataShards] {
+		prng.Read(shard)
+	}
+	if err := enc.Encode(shards); err != nil {
+		t.Fatal(err)
+	}
+	for _, lost := range [][2]int{{7, 123}, {0, 300}, {299, 301}, {300, 301}, {42, 42}} {
+		a, b := lost[0], lost[1]
+		damaged := make([][]byte, len(shards))
+		copy(damaged, shards)
+		damaged[a], damaged[b] = nil, nil
+		if err := enc.Reconstruct(damaged); err != nil {
+			t.Fatalf("lost %d,%d: %v", a, b, err)
+		}
+		for i := range shards {
+			if !equalBytes(damaged[i], shards[i]) {
+				t.Errorf("lost %d,%d: shard %d expected %v, got %v", a, b, i, shards[i], damaged[i])
+			}
+		}
+	}
+
+	// Each wide decode matrix costs two bytes per element.
+	if stats := enc.(*Raid6).CacheStats(); stats.Entries == 0 || stats.Bytes != stats.Entries*2*dataShards*dataShards {
+		t.Errorf("expected %d bytes per cached matrix, got %+v", 2*dataShards*dataShards, stats)
+	}
+
+	odd := make([][]byte, len(shards))
+	for i := range odd {
+		odd[i] = make([]byte, 33)
+	}
+	if err := enc.Encode(odd); err != ErrOddLength {
+		t.Errorf("expected ErrOddLength, got %v", err)
+	}
+	if _, err := Raid6New(MaxRaid6DataShards+1, 2); err != ErrMaxShardNum {
+		t.Errorf("expected ErrMaxShardNum, got %v", err)
+	}
+}
@@ -0,0 +1,224 @@
+package galoisfield
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/rand"
+	"time"
+)
+
+var (
+	ErrGenerationSize = errors.New("generation must hold between 1 and 65535 packets")
+	ErrPacketSize     = errors.New("packet does not match the size of the generation")
+	ErrPacketFormat   = errors.New("malformed coded packet")
+	ErrNotDecoded     = errors.New("not enough independent packets to decode")
+	ErrNoPackets      = errors.New("no innovative packets to recode")
+)
+
+// rlncVersion is the first byte of every encoded CodedPacket.
+const rlncVersion = 1
+
+// CodedPacket is a random linear combination of the k source packets of a
+// generation.  Payload is the sum of Coefficients[i] times source packet i,
+// computed in Default, i.e. GF(256).
+//
+// The binary encoding is a version byte (currently 1), k as a big-endian
+// uint16, the k coefficients, and then the payload, which extends to the end
+// of the data.
+type CodedPacket struct {
+	Coefficients []byte
+	Payload      []byte
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (p CodedPacket) MarshalBinary() ([]byte, error) {
+	k := len(p.Coefficients)
+	if k == 0 || k > 0xffff {
+		return nil, ErrGenerationSize
+	}
+	data := make([]byte, 3+k+len(p.Payload))
+	data[0] = rlncVersion
+	binary.BigEndian.PutUint16(data[1:3], uint16(k))
+	copy(data[3:], p.Coefficients)
+	copy(data[3+k:], p.Payload)
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.  Coefficients and
+// Payload alias data.
+func (p *CodedPacket) UnmarshalBinary(data []byte) error {
+	if len(data) < 3 || data[0] != rlncVersion {
+		return ErrPacketFormat
+	}
+	k := int(binary.BigEndian.Uint16(data[1:3]))
+	if k == 0 || len(data) < 3+k {
+		return ErrPacketFormat
+	}
+	p.Coefficients = data[3 : 3+k]
+	p.Payload = data[3+k:]
+	return nil
+}
+
+// RLNCEncoder produces coded packets for one generation of source packets.
+type RLNCEncoder struct {
+	field  *GF
+	source [][]byte
+	prng   *rand.Rand
+}
+
+// NewRLNCEncoder returns an encoder for the given source packets, which must
+// all be the same size and must not be modified while the encoder is in use.
+// prng supplies the coefficients; if it is nil, a source seeded from the
+// current time is used.  Network coding needs unpredictable coefficients only
+// to resist adversarial loss, so math/rand is sufficient.
+func NewRLNCEncoder(source [][]byte, prng *rand.Rand) (*RLNCEncoder, error) {
+	if len(source) == 0 || len(source) > 0xffff {
+		return nil, ErrGenerationSize
+	}
+	for _, packet := range source {
+		if len(packet) != len(source[0]) {
+			return nil, ErrPacketSize
+		}
+	}
+	return &RLNCEncoder{field: Default, source: source, prng: defaultPRNG(prng)}, nil
+}
+
+// Packet returns a new coded packet with random, not all zero, coefficients.
+func (e *RLNCEncoder) Packet() CodedPacket {
+	coefficients := randomCoefficients(e.prng, len(e.source))
+	payload := make([]byte, len(e.source[0]))
+	for i, c := range coefficients {
+		e.field.MulAddSlice(c, e.source[i], payload)
+	}
+	return CodedPacket{Coefficients: coefficients, Payload: payload}
+}
+
+// RLNCDecoder collects coded packets for one generation and decodes them
+// progressively: each packet is reduced against the ones before it as it
+// arrives, so the source is available as soon as k independent packets have
+// been added.  The packets received so far can also be recoded into new
+// packets at an intermediate node, without first decoding the generation.
+type RLNCDecoder struct {
+	field *GF
+	k     int
+	size  int
+	// rows holds each innovative packet as its coefficients followed by its
+	// payload, kept in reduced row echelon form.  pivots[i] is the column of
+	// the leading 1 of rows[i].
+	rows   [][]byte
+	pivots []int
+}
+
+// NewRLNCDecoder returns a decoder for a generation of k source packets of
+// size bytes each.
+func NewRLNCDecoder(k, size int) (*RLNCDecoder, error) {
+	if k <= 0 || k > 0xffff {
+		return nil, ErrGenerationSize
+	}
+	if size < 0 {
+		return nil, ErrPacketSize
+	}
+	return &RLNCDecoder{field: Default, k: k, size: size}, nil
+}
+
+// AddPacket adds a coded packet to the decoder.  It returns true iff the
+// packet was innovative, i.e. linearly independent of the packets already
+// added, and ErrPacketSize if the packet does not match the generation.
+func (d *RLNCDecoder) AddPacket(p CodedPacket) (bool, error) {
+	if len(p.Coefficients) != d.k || len(p.Payload) != d.size {
+		return false, ErrPacketSize
+	}
+	if d.IsComplete() {
+		return false, nil
+	}
+	row := make([]byte, d.k+d.size)
+	copy(row, p.Coefficients)
+	copy(row[d.k:], p.Payload)
+
+	// Eliminate the existing pivots from the new row.
+	for i, r := range d.rows {
+		if c := row[d.pivots[i]]; c != 0 {
+			d.field.MulAddSlice(c, r, row)
+		}
+	}
+	pivot := -1
+	for col := 0; col < d.k; col++ {
+		if row[col] != 0 {
+			pivot = col
+			break
+		}
+	}
+	if pivot < 0 {
+		return false, nil
+	}
+	// Scale to 1, then eliminate the new pivot from the existing rows.
+	// (Subtraction and addition are both exclusive or in the Galois field.)
+	if row[pivot] != 1 {
+		d.field.MulSlice(d.field.Inv(row[pivot]), row, row)
+	}
+	for _, r := range d.rows {
+		if c := r[pivot]; c != 0 {
+			d.field.MulAddSlice(c, row, r)
+		}
+	}
+	d.rows = append(d.rows, row)
+	d.pivots = append(d.pivots, pivot)
+	return true, nil
+}
+
+// Rank returns the number of innovative packets added so far.
+func (d *RLNCDecoder) Rank() int { return len(d.rows) }
+
+// IsComplete returns true iff the generation can be decoded.
+func (d *RLNCDecoder) IsComplete() bool { return len(d.rows) == d.k }
+
+// Decoded returns the k source packets, or ErrNotDecoded if fewer than k
+// innovative packets have been added.
+func (d *RLNCDecoder) Decoded() ([][]byte, error) {
+	if !d.IsComplete() {
+		return nil, ErrNotDecoded
+	}
+	// With full rank, the coefficients of every row form a unit vector.
+	source := make([][]byte, d.k)
+	for i, r := range d.rows {
+		source[d.pivots[i]] = append([]byte(nil), r[d.k:]...)
+	}
+	return source, nil
+}
+
+// Recode returns a new coded packet that is a random combination of the
+// packets added so far, for forwarding to the next hop.  The result is over
+// the original source packets, so the next hop needs no knowledge of this
+// one.  prng may be nil, as for NewRLNCEncoder.  It returns ErrNoPackets if
+// no innovative packets have been added.
+func (d *RLNCDecoder) Recode(prng *rand.Rand) (CodedPacket, error) {
+	if len(d.rows) == 0 {
+		return CodedPacket{}, ErrNoPackets
+	}
+	// The rows are independent, so a nonzero combination of them is too.
+	row := make([]byte, d.k+d.size)
+	for i, c := range randomCoefficients(defaultPRNG(prng), len(d.rows)) {
+		d.field.MulAddSlice(c, d.rows[i], row)
+	}
+	return CodedPacket{Coefficients: row[:d.k:d.k], Payload: row[d.k:]}, nil
+}
+
+// randomCoefficients returns n random bytes, not all zero.
+func randomCoefficients(prng *rand.Rand, n int) []byte {
+	coefficients := make([]byte, n)
+	for {
+		prng.Read(coefficients)
+		for _, c := range coefficients {
+			if c != 0 {
+				return coefficients
+			}
+		}
+	}
+}
+
+func defaultPRNG(prng *rand.Rand) *rand.Rand {
+	if prng == nil {
+		prng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return prng
+}
@@ -0,0 +1,187 @@
+package galoisfield
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+func randomSource(prng *rand.Rand, k, size int) [][]byte {
+	source := make([][]byte, k)
+	for i := range source {
+		source[i] = make([]byte, size)
+		prng.Read(source[i])
+	}
+	return source
+}
+
+func TestRLNC(t *testing.T) {
+	prng := rand.New(rand.NewSource(42))
+	type testrow struct {
+		k, size int
+	}
+	for _, row := range []testrow{
+		testrow{1, 1},
+		testrow{4, 100},
+		testrow{16, 1000},
+		testrow{64, 333},
+	} {
+		source := randomSource(prng, row.k, row.size)
+		enc, err := NewRLNCEncoder(source, prng)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dec, err := NewRLNCDecoder(row.k, row.size)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sent := 0
+		for !dec.IsComplete() {
+			if sent > 2*row.k+16 {
+				t.Fatalf("[k=%d] not decoded after %d packets, rank %d", row.k, sent, dec.Rank())
+			}
+			sent++
+			rank := dec.Rank()
+			innovative, err := dec.AddPacket(enc.Packet())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if innovative != (dec.Rank() == rank+1) {
+				t.Fatalf("[k=%d] innovative=%v, but rank went from %d to %d", row.k, innovative, rank, dec.Rank())
+			}
+		}
+		checkDecoded(t, dec, source)
+	}
+}
+
+func TestRLNC_recode_with_loss(t *testing.T) {
+	prng := rand.New(rand.NewSource(42))
+	const k, size = 32, 512
+	source := randomSource(prng, k, size)
+	enc, _ := NewRLNCEncoder(source, prng)
+	relay, _ := NewRLNCDecoder(k, size)
+	sink, _ := NewRLNCDecoder(k, size)
+
+	// Each hop drops 30% of the packets, and every packet crosses the wire
+	// in its binary form.
+	hop := func(p CodedPacket, to *RLNCDecoder) {
+		if prng.Float64() < 0.3 {
+			return
+		}
+		data, err := p.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var q CodedPacket
+		if err := q.UnmarshalBinary(data); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := to.AddPacket(q); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for round := 0; !sink.IsComplete(); round++ {
+		if round > 10*k {
+			t.Fatalf("not decoded after %d rounds, relay rank %d, sink rank %d", round, relay.Rank(), sink.Rank())
+		}
+		hop(enc.Packet(), relay)
+		if relay.Rank() > 0 {
+			p, err := relay.Recode(prng)
+			if err != nil {
+				t.Fatal(err)
+			}
+			hop(p, sink)
+		}
+		if sink.Rank() > relay.Rank() {
+			t.Fatalf("sink rank %d exceeds relay rank %d", sink.Rank(), relay.Rank())
+		}
+	}
+	checkDecoded(t, sink, source)
+}
+
+func TestRLNC_errors(t *testing.T) {
+	if _, err := NewRLNCEncoder(nil, nil); err != ErrGenerationSize {
+		t.Errorf("expected ErrGenerationSize, got %v", err)
+	}
+	if _, err := NewRLNCEncoder([][]byte{{1, 2}, {3}}, nil); err != ErrPacketSize {
+		t.Errorf("expected ErrPacketSize, got %v", err)
+	}
+	for _, k := range []int{0, 1 << 16} {
+		if _, err := NewRLNCDecoder(k, 10); err != ErrGenerationSize {
+			t.Errorf("NewRLNCDecoder(%d, 10): expected ErrGenerationSize, got %v", k, err)
+		}
+	}
+
+	dec, _ := NewRLNCDecoder(2, 3)
+	if _, err := dec.Decoded(); err != ErrNotDecoded {
+		t.Errorf("expected ErrNotDecoded, got %v", err)
+	}
+	if _, err := dec.Recode(nil); err != ErrNoPackets {
+		t.Errorf("expected ErrNoPackets, got %v", err)
+	}
+	if _, err := dec.AddPacket(CodedPacket{[]byte{1}, []byte{1, 2, 3}}); err != ErrPacketSize {
+		t.Errorf("expected ErrPacketSize, got %v", err)
+	}
+	if _, err := dec.AddPacket(CodedPacket{[]byte{1, 0}, []byte{1, 2}}); err != ErrPacketSize {
+		t.Errorf("expected ErrPacketSize, got %v", err)
+	}
+	// A multiple of an earlier packet is not innovative.
+	if ok, _ := dec.AddPacket(CodedPacket{[]byte{1, 2}, []byte{1, 2, 3}}); !ok {
+		t.Errorf("expected the first packet to be innovative")
+	}
+	p := CodedPacket{[]byte{2, 4}, make([]byte, 3)}
+	Default.MulSlice(2, []byte{1, 2, 3}, p.Payload)
+	if ok, _ := dec.AddPacket(p); ok || dec.Rank() != 1 {
+		t.Errorf("expected a dependent packet to be rejected, got %v with rank %d", ok, dec.Rank())
+	}
+
+	var q CodedPacket
+	for _, data := range [][]byte{nil, {1, 0}, {2, 0, 1, 5}, {1, 0, 0, 5}, {1, 0, 3, 1, 2}} {
+		if err := q.UnmarshalBinary(data); err != ErrPacketFormat {
+			t.Errorf("UnmarshalBinary(%v): expected ErrPacketFormat, got %v", data, err)
+		}
+	}
+	if err := q.UnmarshalBinary([]byte{1, 0, 2, 7, 8, 9}); err != nil ||
+		!bytes.Equal(q.Coefficients, []byte{7, 8}) || !bytes.Equal(q.Payload, []byte{9}) {
+		t.Errorf("expected {[7 8] [9]}, got %v, %v", q, err)
+	}
+	if _, err := (CodedPacket{}).MarshalBinary(); err != ErrGenerationSize {
+		t.Errorf("expected ErrGenerationSize, got %v", err)
+	}
+}
+
+func checkDecoded(t *testing.T, dec *RLNCDecoder, source [][]byte) {
+	t.Helper()
+	decoded, err := dec.Decoded()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range source {
+		if !bytes.Equal(decoded[i], source[i]) {
+			t.Errorf("packet %d: expected %v, got %v", i, source[i], decoded[i])
+		}
+	}
+}
+
+func BenchmarkRLNCDecoder_32x1K(b *testing.B) {
+	prng := rand.New(rand.NewSource(42))
+	const k, size = 32, 1 << 10
+	enc, _ := NewRLNCEncoder(randomSource(prng, k, size), prng)
+	packets := make([]CodedPacket, k+4)
+	for i := range packets {
+		packets[i] = enc.Packet()
+	}
+	b.SetBytes(k * size)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dec, _ := NewRLNCDecoder(k, size)
+		for _, p := range packets {
+			if _, err := dec.AddPacket(p); err != nil {
+				b.Fatal(err)
+			}
+		}
+		if !dec.IsComplete() {
+			b.Fatal("not decoded")
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package galoisfield
+
+import (
+	"errors"
+)
+
+var (
+	ErrCodeLength    = errors.New("code length must satisfy 0 < k < n < field size")
+	ErrMessageSize   = errors.New("message length does not match the code")
+	ErrCodewordSize  = errors.New("codeword length does not match the code")
+	ErrErasureIndex  = errors.New("erasure index is out of range or repeated")
+	ErrSymbolRange   = errors.New("symbol is not an element of the field")
+	ErrTooManyErrors = errors.New("too many errors to correct")
+)
+
+// RSCodec is a classic systematic Reed-Solomon RS(n, k) codec.  Unlike Raid6,
+// which can only rebuild shards that are known to be missing, it locates and
+// corrects corrupted symbols within a single codeword: up to t errors and e
+// erasures (symbols known to be bad) as long as 2t + e ≤ n - k.
+//
+// A codeword is n symbols: the k message symbols followed by n-k parity
+// symbols.  Symbol i is the coefficient of x**(n-1-i) of the codeword
+// polynomial, which is a multiple of the generator polynomial
+//
+//	(x - α**0) (x - α**1) ... (x - α**(n-k-1))
+//
+// where α is the generator of the field.
+type RSCodec struct {
+	field     *GF
+	n, k      int
+	generator Polynomial
+}
+
+// NewRSCodec returns a codec for codewords of n symbols carrying k message
+// symbols each.  If field is nil, Default is used.  It returns ErrCodeLength
+// unless 0 < k < n < field.Size().
+func NewRSCodec(field *GF, n, k int) (*RSCodec, error) {
+	if field == nil {
+		field = Default
+	}
+	if k <= 0 || n <= k || uint(n) >= field.Size() {
+		return nil, ErrCodeLength
+	}
+	generator := NewPolynomial(field, 1)
+	for i := 0; i < n-k; i++ {
+		generator = generator.Mul(NewPolynomial(field, field.Exp(byte(i)), 1))
+	}
+	return &RSCodec{field: field, n: n, k: k, generator: generator}, nil
+}
+
+// N returns the number of symbols in a codeword.
+func (rs *RSCodec) N() int { return rs.n }
+
+// K returns the number of message symbols in a codeword.
+func (rs *RSCodec) K() int { return rs.k }
+
+// Generator returns the generator polynomial of the code.
+func (rs *RSCodec) Generator() Polynomial { return rs.generator }
+
+// Encode returns the codeword for a message of exactly K symbols.  It returns
+// ErrSymbolRange if a symbol is not an element of the field.
+func (rs *RSCodec) Encode(message []byte) ([]byte, error) {
+	if len(message) != rs.k {
+		return nil, ErrMessageSize
+	}
+	if !rs.inField(message) {
+		return nil, ErrSymbolRange
+	}
+	nsym := rs.n - rs.k
+	codeword := make([]byte, rs.n)
+	copy(codeword, message)
+	parity, _ := rs.polynomial(codeword).Mod(rs.generator)
+	for j := 0; j < nsym; j++ {
+		codeword[rs.k+j] = parity.Coefficient(uint(nsym - 1 - j))
+	}
+	return codeword, nil
+}
+
+// Decode corrects codeword in place and returns the number of symbols it
+// changed.  erasures lists the indices of symbols known to be bad; they count
+// against the correction capacity at half the cost of unknown errors.
+//
+// If the codeword cannot be corrected, Decode returns ErrTooManyErrors and
+// leaves it unmodified; likewise ErrSymbolRange if a symbol is not an element
+// of the field.  Note that a codeword with more errors than the code can
+// correct may instead be "corrected" to a different valid codeword.
+func (rs *RSCodec) Decode(codeword []byte, erasures []int) (int, error) {
+	if len(codeword) != rs.n {
+		return 0, ErrCodewordSize
+	}
+	nsym := rs.n - rs.k
+	var seen = make(map[int]bool, len(erasures))
+	for _, i := range erasures {
+		if i < 0 || i >= rs.n || seen[i] {
+			return 0, ErrErasureIndex
+		}
+		seen[i] = true
+	}
+	if len(erasures) > nsym {
+		return 0, ErrTooManyErrors
+	}
+	if !rs.inField(codeword) {
+		return 0, ErrSymbolRange
+	}
+
+	syndromes, ok := rs.syndromes(codeword)
+	if ok {
+		return 0, nil
+	}
+	field := rs.field
+
+	// The erasure locator has a root at X**-1 for the locator X of each
+	// erased symbol.
+	locator := NewPolynomial(field, 1)
+	for _, i := range erasures {
+		locator = locator.Mul(NewPolynomial(field, 1, rs.locator(i)))
+	}
+
+	// Berlekamp-Massey, seeded with the erasure locator, finds the combined
+	// error-and-erasure locator.
+	ne := len(erasures)
+	prev := locator
+	L := ne
+	for r := ne; r < nsym; r++ {
+		var delta byte
+		for i, k := range locator.coefficients {
+			if i > r {
+				break
+			}
+			delta ^= field.Mul(k, syndromes[r-i])
+		}
+		prev = prev.Mul(NewPolynomial(field, 0, 1))
+		if delta == 0 {
+			continue
+		}
+		next := locator.Add(prev.Scale(delta))
+		if 2*L <= r+ne {
+			prev = locator.Scale(field.Inv(delta))
+			L = r + 1 + ne - L
+		}
+		locator = next
+	}
+	if 2*(L-ne)+ne > nsym || int(locator.Degree()) != L {
+		return 0, ErrTooManyErrors
+	}
+
+	// Chien search: find every position whose locator is a root.
+	var positions []int
+	for i := 0; i < rs.n; i++ {
+		if locator.Evaluate(field.Inv(rs.locator(i))) == 0 {
+			positions = append(positions, i)
+		}
+	}
+	if len(positions) != L {
+		return 0, ErrTooManyErrors
+	}
+
+	// Forney: the error evaluator is S(x)Λ(x) mod x**nsym.
+	evaluator := NewPolynomial(field, syndromes...).Mul(locator)
+	if len(evaluator.coefficients) > nsym {
+		evaluator = NewPolynomial(field, evaluator.coefficients[:nsym]...)
+	}
+	derivative := locator.Derivative()
+
+	corrected := append([]byte(nil), codeword...)
+	changed := 0
+	for _, i := range positions {
+		X := rs.locator(i)
+		Xinv := field.Inv(X)
+		denom := derivative.Evaluate(Xinv)
+		if denom == 0 {
+			return 0, ErrTooManyErrors
+		}
+		e := field.Mul(X, field.Div(evaluator.Evaluate(Xinv), denom))
+		if e != 0 {
+			corrected[i] ^= e
+			changed++
+		}
+	}
+	if _, ok := rs.syndromes(corrected); !ok {
+		return 0, ErrTooManyErrors
+	}
+	copy(codeword, corrected)
+	return changed, nil
+}
+
+// polynomial returns the codeword polynomial for a slice of n symbols.
+func (rs *RSCodec) polynomial(codeword []byte) Polynomial {
+	coefficients := make([]byte, len(codeword))
+	for i, c := range codeword {
+		coefficients[len(codeword)-1-i] = c
+	}
+	return NewPolynomial(rs.field, coefficients...)
+}
+
+// syndromes evaluates the codeword at each root of the generator.  ok is true
+// iff every syndrome is zero, i.e. the codeword is valid.
+func (rs *RSCodec) syndromes(codeword []byte) (syndromes []byte, ok bool) {
+	p := rs.polynomial(codeword)
+	syndromes = make([]byte, rs.n-rs.k)
+	ok = true
+	for j := range syndromes {
+		syndromes[j] = p.Evaluate(rs.field.Exp(byte(j)))
+		if syndromes[j] != 0 {
+			ok = false
+		}
+	}
+	return syndromes, ok
+}
+
+// locator returns α**(n-1-i), the error locator for symbol i.
+func (rs *RSCodec) locator(i int) byte {
+	return rs.field.Exp(byte(rs.n - 1 - i))
+}
+
+// inField returns true iff every symbol is an element of the field.
+func (rs *RSCodec) inField(symbols []byte) bool {
+	for _, x := range symbols {
+		if uint(x) >= rs.field.Size() {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,172 @@
+package galoisfield
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+func TestRSCodec(t *testing.T) {
+	prng := rand.New(rand.NewSource(42))
+	type testrow struct {
+		field *GF
+		n, k  int
+	}
+	for _, row := range []testrow{
+		testrow{Poly410_g2, 15, 11},
+		testrow{Poly610_g7, 40, 30},
+		testrow{Poly84310_g3, 32, 20},
+		testrow{Default, 255, 223},
+		testrow{Default, 20, 4},
+	} {
+		rs, err := NewRSCodec(row.field, row.n, row.k)
+		if err != nil {
+			t.Fatalf("%#v (%d,%d): unexpected error %v", row.field, row.n, row.k, err)
+		}
+		nsym := row.n - row.k
+		for trial := 0; trial < 32; trial++ {
+			message := make([]byte, row.k)
+			for i := range message {
+				message[i] = byte(prng.Intn(int(row.field.Size())))
+			}
+			codeword, err := rs.Encode(message)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(codeword[:row.k], message) {
+				t.Fatalf("%#v (%d,%d): encoding is not systematic", row.field, row.n, row.k)
+			}
+			if r, _ := rs.polynomial(codeword).Mod(rs.Generator()); !r.IsZero() {
+				t.Fatalf("%#v (%d,%d): codeword is not a multiple of the generator", row.field, row.n, row.k)
+			}
+
+			// Spend the full budget on a random mix of errors and erasures.
+			ne := prng.Intn(nsym + 1)
+			nerr := (nsym - ne) / 2
+			received := append([]byte(nil), codeword...)
+			bad := prng.Perm(row.n)[:ne+nerr]
+			erasures := bad[:ne]
+			for _, i := range bad {
+				received[i] ^= byte(1 + prng.Intn(int(row.field.Size())-1))
+			}
+			changed, err := rs.Decode(received, erasures)
+			if err != nil {
+				t.Fatalf("%#v (%d,%d): %d erasures, %d errors: unexpected error %v", row.field, row.n, row.k, ne, nerr, err)
+			}
+			if !bytes.Equal(received, codeword) {
+				t.Errorf("%#v (%d,%d): %d erasures, %d errors: expected %v, got %v", row.field, row.n, row.k, ne, nerr, codeword, received)
+			}
+			if changed != ne+nerr {
+				t.Errorf("%#v (%d,%d): expected %d changed symbols, got %d", row.field, row.n, row.k, ne+nerr, changed)
+			}
+		}
+	}
+}
+
+func TestRSCodec_Decode_clean(t *testing.T) {
+	rs, err := NewRSCodec(nil, 10, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	codeword, _ := rs.Encode([]byte("abcdef"))
+	expect := append([]byte(nil), codeword...)
+	if changed, err := rs.Decode(codeword, []int{0, 9}); changed != 0 || err != nil {
+		t.Errorf("expected 0, nil, got %d, %v", changed, err)
+	}
+	if !bytes.Equal(codeword, expect) {
+		t.Errorf("expected %v, got %v", expect, codeword)
+	}
+}
+
+func TestRSCodec_errors(t *testing.T) {
+	for _, nk := range [][2]int{{10, 0}, {10, 10}, {256, 200}} {
+		if _, err := NewRSCodec(nil, nk[0], nk[1]); err != ErrCodeLength {
+			t.Errorf("NewRSCodec(%d, %d): expected ErrCodeLength, got %v", nk[0], nk[1], err)
+		}
+	}
+	if _, err := NewRSCodec(Poly410_g2, 16, 8); err != ErrCodeLength {
+		t.Errorf("expected ErrCodeLength, got %v", err)
+	}
+
+	rs, _ := NewRSCodec(nil, 10, 6)
+	if _, err := rs.Encode([]byte("abc")); err != ErrMessageSize {
+		t.Errorf("expected ErrMessageSize, got %v", err)
+	}
+	codeword, _ := rs.Encode([]byte("abcdef"))
+	if _, err := rs.Decode(codeword[:9], nil); err != ErrCodewordSize {
+		t.Errorf("expected ErrCodewordSize, got %v", err)
+	}
+	for _, erasures := range [][]int{{-1}, {10}, {3, 3}} {
+		if _, err := rs.Decode(codeword, erasures); err != ErrErasureIndex {
+			t.Errorf("erasures %v: expected ErrErasureIndex, got %v", erasures, err)
+		}
+	}
+	if _, err := rs.Decode(codeword, []int{0, 1, 2, 3, 4}); err != ErrTooManyErrors {
+		t.Errorf("expected ErrTooManyErrors, got %v", err)
+	}
+
+	// A symbol outside a small field is rejected, not indexed into a table.
+	small, _ := NewRSCodec(Poly410_g2, 15, 11)
+	if _, err := small.Encode([]byte{1, 2, 3, 16, 5, 6, 7, 8, 9, 10, 11}); err != ErrSymbolRange {
+		t.Errorf("expected ErrSymbolRange, got %v", err)
+	}
+	received, _ := small.Encode([]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11})
+	received[3] = 0xff
+	before := append([]byte(nil), received...)
+	if _, err := small.Decode(received, []int{3}); err != ErrSymbolRange {
+		t.Errorf("expected ErrSymbolRange, got %v", err)
+	}
+	if !bytes.Equal(received, before) {
+		t.Errorf("rejected decode modified the codeword")
+	}
+
+	// Three errors exceed the capacity of two; the decoder must either
+	// report failure and leave the codeword alone, or land on some other
+	// valid codeword.
+	prng := rand.New(rand.NewSource(42))
+	failures := 0
+	for trial := 0; trial < 256; trial++ {
+		received := append([]byte(nil), codeword...)
+		for _, i := range prng.Perm(10)[:3] {
+			received[i] ^= byte(1 + prng.Intn(255))
+		}
+		before := append([]byte(nil), received...)
+		_, err := rs.Decode(received, nil)
+		switch {
+		case err == ErrTooManyErrors:
+			failures++
+			if !bytes.Equal(received, before) {
+				t.Fatalf("failed decode modified the codeword")
+			}
+		case err != nil:
+			t.Fatalf("unexpected error %v", err)
+		default:
+			if _, ok := rs.syndromes(received); !ok || bytes.Equal(received, codeword) {
+				t.Fatalf("expected a different valid codeword, got %v", received)
+			}
+		}
+	}
+	if failures == 0 {
+		t.Errorf("expected some decodes to fail")
+	}
+}
+
+func BenchmarkRSCodec_Decode(b *testing.B) {
+	rs, _ := NewRSCodec(nil, 255, 223)
+	prng := rand.New(rand.NewSource(42))
+	message := make([]byte, 223)
+	prng.Read(message)
+	codeword, _ := rs.Encode(message)
+	received := make([]byte, len(codeword))
+	b.SetBytes(int64(len(codeword)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(received, codeword)
+		for j := 0; j < 16; j++ {
+			received[j*15] ^= 0x5a
+		}
+		if _, err := rs.Decode(received, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
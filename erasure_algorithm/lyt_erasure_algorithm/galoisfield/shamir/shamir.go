@@ -0,0 +1,165 @@
+// Package shamir implements Shamir's secret sharing over GF(256).
+//
+// Split turns a secret into n shares, any k of which recover it with Combine;
+// fewer than k shares reveal nothing about the secret.  Each byte of the
+// secret is the constant term of its own random polynomial of degree k-1 over
+// galoisfield.Default, and each share holds the values of those polynomials at
+// the share's x-coordinate.
+//
+// Arithmetic on secret values goes through galoisfield.ConstantTimeGF, so the
+// running time does not depend on the secret.  Only the public x-coordinates
+// are handled with the table-driven field.
+package shamir
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+
+	"example.com/galoisfield"
+)
+
+var (
+	ErrShareCount     = errors.New("need 1 ≤ k ≤ n ≤ 255 shares")
+	ErrEmptySecret    = errors.New("secret is empty")
+	ErrTooFewShares   = errors.New("too few shares to recover the secret")
+	ErrDuplicateShare = errors.New("two shares have the same x-coordinate")
+	ErrShareMismatch  = errors.New("shares disagree on threshold or length")
+	ErrInvalidShare   = errors.New("share is malformed")
+)
+
+// Share is one share of a secret.
+type Share struct {
+	X         byte   // x-coordinate, never 0
+	Threshold byte   // number of shares needed to recover the secret
+	Y         []byte // one value per byte of the secret
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.  The encoding is X,
+// then Threshold, then Y.
+func (s Share) MarshalBinary() ([]byte, error) {
+	if s.X == 0 || s.Threshold == 0 || len(s.Y) == 0 {
+		return nil, ErrInvalidShare
+	}
+	data := make([]byte, 2+len(s.Y))
+	data[0] = s.X
+	data[1] = s.Threshold
+	copy(data[2:], s.Y)
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+func (s *Share) UnmarshalBinary(data []byte) error {
+	if len(data) < 3 || data[0] == 0 || data[1] == 0 {
+		return ErrInvalidShare
+	}
+	s.X = data[0]
+	s.Threshold = data[1]
+	s.Y = append([]byte(nil), data[2:]...)
+	return nil
+}
+
+// Split divides secret into n shares, any k of which can recover it.  The
+// shares have x-coordinates 1 through n.
+func Split(secret []byte, n, k int) ([]Share, error) {
+	return split(secret, n, k, rand.Reader)
+}
+
+func split(secret []byte, n, k int, random io.Reader) ([]Share, error) {
+	if k < 1 || n < k || n > 255 {
+		return nil, ErrShareCount
+	}
+	if len(secret) == 0 {
+		return nil, ErrEmptySecret
+	}
+	ct := galoisfield.Default.ConstantTime()
+
+	shares := make([]Share, n)
+	for i := range shares {
+		shares[i] = Share{
+			X:         byte(i + 1),
+			Threshold: byte(k),
+			Y:         make([]byte, len(secret)),
+		}
+	}
+	coefficients := make([]byte, k)
+	for b, s := range secret {
+		coefficients[0] = s
+		if _, err := io.ReadFull(random, coefficients[1:]); err != nil {
+			return nil, err
+		}
+		for i := range shares {
+			shares[i].Y[b] = evaluate(ct, coefficients, shares[i].X)
+		}
+	}
+	for i := range coefficients {
+		coefficients[i] = 0
+	}
+	return shares, nil
+}
+
+// Combine recovers the secret from at least Threshold shares.  Extra shares
+// beyond the threshold are ignored.
+func Combine(shares []Share) ([]byte, error) {
+	if len(shares) == 0 {
+		return nil, ErrTooFewShares
+	}
+	k := int(shares[0].Threshold)
+	size := len(shares[0].Y)
+	var seen [256]bool
+	for _, s := range shares {
+		if s.X == 0 || s.Threshold == 0 || len(s.Y) == 0 {
+			return nil, ErrInvalidShare
+		}
+		if int(s.Threshold) != k || len(s.Y) != size {
+			return nil, ErrShareMismatch
+		}
+		if seen[s.X] {
+			return nil, ErrDuplicateShare
+		}
+		seen[s.X] = true
+	}
+	if len(shares) < k {
+		return nil, ErrTooFewShares
+	}
+	shares = shares[:k]
+
+	// The Lagrange weights for evaluating at 0 depend only on the public
+	// x-coordinates: w_i = ∏_{j≠i} x_j / (x_i - x_j).
+	gf := galoisfield.Default
+	weights := make([]byte, k)
+	for i := range shares {
+		var num, denom byte = 1, 1
+		for j := range shares {
+			if j == i {
+				continue
+			}
+			num = gf.Mul(num, shares[j].X)
+			denom = gf.Mul(denom, gf.Add(shares[i].X, shares[j].X))
+		}
+		weights[i] = gf.Div(num, denom)
+	}
+
+	ct := gf.ConstantTime()
+	secret := make([]byte, size)
+	for b := range secret {
+		var sum byte
+		for i, s := range shares {
+			sum ^= ct.Mul(weights[i], s.Y[b])
+		}
+		secret[b] = sum
+	}
+	return secret, nil
+}
+
+// evaluate computes the polynomial with the given coefficients, lowest degree
+// first, at x by Horner's rule in constant time.  It always runs over every
+// coefficient, even zero ones at the top, so that the work done does not
+// reveal the degree.
+func evaluate(ct galoisfield.ConstantTimeGF, coefficients []byte, x byte) byte {
+	var y byte
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		y = ct.Add(ct.Mul(y, x), coefficients[i])
+	}
+	return y
+}
@@ -0,0 +1,160 @@
+package shamir
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"example.com/galoisfield"
+)
+
+func TestSplitCombine(t *testing.T) {
+	prng := rand.New(rand.NewSource(42))
+	secret := []byte("correct horse battery staple, 32")
+	type testrow struct {
+		n, k int
+	}
+	for _, row := range []testrow{
+		testrow{1, 1},
+		testrow{3, 1},
+		testrow{3, 2},
+		testrow{5, 3},
+		testrow{6, 6},
+		testrow{255, 17},
+	} {
+		shares, err := split(secret, row.n, row.k, prng)
+		if err != nil {
+			t.Fatalf("[n=%d,k=%d] unexpected error %v", row.n, row.k, err)
+		}
+		if len(shares) != row.n {
+			t.Fatalf("[n=%d,k=%d] expected %d shares, got %d", row.n, row.k, row.n, len(shares))
+		}
+		for trial := 0; trial < 16; trial++ {
+			subset := make([]Share, row.k)
+			for i, j := range prng.Perm(row.n)[:row.k] {
+				subset[i] = shares[j]
+			}
+			recovered, err := Combine(subset)
+			if err != nil {
+				t.Fatalf("[n=%d,k=%d] unexpected error %v", row.n, row.k, err)
+			}
+			if !bytes.Equal(recovered, secret) {
+				t.Errorf("[n=%d,k=%d] expected %q, got %q", row.n, row.k, secret, recovered)
+			}
+		}
+		if row.k > 1 {
+			if _, err := Combine(shares[:row.k-1]); err != ErrTooFewShares {
+				t.Errorf("[n=%d,k=%d] expected ErrTooFewShares, got %v", row.n, row.k, err)
+			}
+		}
+	}
+}
+
+func TestSplit_crypto_rand(t *testing.T) {
+	secret := []byte{0, 1, 2, 0xff}
+	shares, err := Split(secret, 5, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	recovered, err := Combine([]Share{shares[4], shares[0], shares[2]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(recovered, secret) {
+		t.Errorf("expected %v, got %v", secret, recovered)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	prng := rand.New(rand.NewSource(42))
+	gf := galoisfield.Default
+	ct := gf.ConstantTime()
+	for trial := 0; trial < 256; trial++ {
+		coefficients := make([]byte, 1+prng.Intn(8))
+		prng.Read(coefficients)
+		// Zero some of the top coefficients, which NewPolynomial would trim.
+		for i := len(coefficients) - 1 - prng.Intn(len(coefficients)); i < len(coefficients); i++ {
+			coefficients[i] = 0
+		}
+		p := galoisfield.NewPolynomial(gf, coefficients...)
+		for x := 0; x < 256; x++ {
+			if y, expect := evaluate(ct, coefficients, byte(x)), p.Evaluate(byte(x)); y != expect {
+				t.Fatalf("%v at %d: expected %d, got %d", coefficients, x, expect, y)
+			}
+		}
+	}
+}
+
+func TestSplit_errors(t *testing.T) {
+	type testrow struct {
+		secret []byte
+		n, k   int
+		expect error
+	}
+	for _, row := range []testrow{
+		testrow{[]byte{1}, 3, 0, ErrShareCount},
+		testrow{[]byte{1}, 2, 3, ErrShareCount},
+		testrow{[]byte{1}, 256, 3, ErrShareCount},
+		testrow{nil, 3, 2, ErrEmptySecret},
+	} {
+		if _, err := Split(row.secret, row.n, row.k); err != row.expect {
+			t.Errorf("Split(%v, %d, %d): expected %v, got %v", row.secret, row.n, row.k, row.expect, err)
+		}
+	}
+}
+
+func TestCombine_errors(t *testing.T) {
+	shares, err := Split([]byte("secret"), 4, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := Split([]byte("other secret"), 4, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	type testrow struct {
+		shares []Share
+		expect error
+	}
+	for idx, row := range []testrow{
+		testrow{nil, ErrTooFewShares},
+		testrow{shares[:1], ErrTooFewShares},
+		testrow{[]Share{shares[1], shares[1]}, ErrDuplicateShare},
+		testrow{[]Share{shares[0], other[1]}, ErrShareMismatch},
+		testrow{[]Share{shares[0], Share{X: 0, Threshold: 2, Y: shares[1].Y}}, ErrInvalidShare},
+	} {
+		if _, err := Combine(row.shares); err != row.expect {
+			t.Errorf("[%d] expected %v, got %v", idx, row.expect, err)
+		}
+	}
+}
+
+func TestShare_MarshalBinary(t *testing.T) {
+	shares, err := Split([]byte("secret"), 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := make([]Share, len(shares))
+	for i, s := range shares {
+		data, err := s.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(data) != 2+len(s.Y) || data[0] != s.X || data[1] != 2 {
+			t.Errorf("unexpected encoding %v for share %+v", data, s)
+		}
+		if err := decoded[i].UnmarshalBinary(data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	recovered, err := Combine(decoded[1:])
+	if err != nil || string(recovered) != "secret" {
+		t.Errorf("expected %q, got %q, %v", "secret", recovered, err)
+	}
+	var s Share
+	for _, data := range [][]byte{nil, {1, 2}, {0, 2, 3}, {1, 0, 3}} {
+		if err := s.UnmarshalBinary(data); err != ErrInvalidShare {
+			t.Errorf("UnmarshalBinary(%v): expected ErrInvalidShare, got %v", data, err)
+		}
+	}
+}
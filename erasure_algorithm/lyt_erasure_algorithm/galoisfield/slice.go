@@ -0,0 +1,111 @@
+package galoisfield
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+var (
+	ErrSliceLength = errors.New("output slice is shorter than input slice")
+)
+
+// nibbleTables returns the products c*i and c*(i<<4) for every 4-bit i, so
+// that c*x == low[x&15] ^ high[x>>4] for any field element x.
+func (gf *GF) nibbleTables(c byte) (low, high [16]byte) {
+	n := gf.Size()
+	for i := uint(0); i < 16; i++ {
+		if i < n {
+			low[i] = gf.Mul(c, byte(i))
+		}
+		if i<<4 < n {
+			high[i] = gf.Mul(c, byte(i<<4))
+		}
+	}
+	return
+}
+
+// MulSlice sets out[i] = c*in[i] for every i in [0..len(in)).
+//
+// The elements of in must belong to the field, and out must be at least as
+// long as in or this function will panic.
+func (gf *GF) MulSlice(c byte, in, out []byte) {
+	if len(out) < len(in) {
+		panic(ErrSliceLength)
+	}
+	out = out[:len(in)]
+	switch c {
+	case 0:
+		for i := range out {
+			out[i] = 0
+		}
+		return
+	case 1:
+		copy(out, in)
+		return
+	}
+	low, high := gf.nibbleTables(c)
+	for len(in) >= 8 {
+		binary.LittleEndian.PutUint64(out, mulWord(&low, &high, in))
+		in, out = in[8:], out[8:]
+	}
+	for i, x := range in {
+		out[i] = low[x&15] ^ high[x>>4]
+	}
+}
+
+// MulAddSlice sets out[i] = out[i] + c*in[i] for every i in [0..len(in)).
+//
+// The elements of in must belong to the field, and out must be at least as
+// long as in or this function will panic.
+func (gf *GF) MulAddSlice(c byte, in, out []byte) {
+	if len(out) < len(in) {
+		panic(ErrSliceLength)
+	}
+	switch c {
+	case 0:
+		return
+	case 1:
+		gf.AddSlice(in, out)
+		return
+	}
+	low, high := gf.nibbleTables(c)
+	for len(in) >= 8 {
+		word := binary.LittleEndian.Uint64(out) ^ mulWord(&low, &high, in)
+		binary.LittleEndian.PutUint64(out, word)
+		in, out = in[8:], out[8:]
+	}
+	for i, x := range in {
+		out[i] ^= low[x&15] ^ high[x>>4]
+	}
+}
+
+// AddSlice sets out[i] = out[i] + in[i] for every i in [0..len(in)).
+//
+// out must be at least as long as in or this function will panic.
+func (_ *GF) AddSlice(in, out []byte) {
+	if len(out) < len(in) {
+		panic(ErrSliceLength)
+	}
+	for len(in) >= 8 {
+		word := binary.LittleEndian.Uint64(out) ^ binary.LittleEndian.Uint64(in)
+		binary.LittleEndian.PutUint64(out, word)
+		in, out = in[8:], out[8:]
+	}
+	for i, x := range in {
+		out[i] ^= x
+	}
+}
+
+// mulWord multiplies the first 8 bytes of in by the coefficient described by
+// the given nibble tables, returning the products packed little-endian.
+func mulWord(low, high *[16]byte, in []byte) uint64 {
+	_ = in[7]
+	return uint64(low[in[0]&15]^high[in[0]>>4]) |
+		uint64(low[in[1]&15]^high[in[1]>>4])<<8 |
+		uint64(low[in[2]&15]^high[in[2]>>4])<<16 |
+		uint64(low[in[3]&15]^high[in[3]>>4])<<24 |
+		uint64(low[in[4]&15]^high[in[4]>>4])<<32 |
+		uint64(low[in[5]&15]^high[in[5]>>4])<<40 |
+		uint64(low[in[6]&15]^high[in[6]>>4])<<48 |
+		uint64(low[in[7]&15]^high[in[7]>>4])<<56
+}
@@ -0,0 +1,76 @@
+package galoisfield
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestGF_MulSlice(t *testing.T) {
+	var prng = rand.New(rand.NewSource(42))
+	for _, wk := range wellknown[1:] {
+		field := wk.field
+		for _, size := range []int{0, 1, 7, 8, 9, 31, 64} {
+			in := make([]byte, size)
+			for i := range in {
+				in[i] = byte(prng.Intn(int(field.Size())))
+			}
+			for c := uint(0); c < field.Size(); c++ {
+				out := make([]byte, size)
+				prng.Read(out)
+				orig := append([]byte(nil), out...)
+				field.MulSlice(byte(c), in, out)
+				for i := range in {
+					expect := field.Mul(byte(c), in[i])
+					if out[i] != expect {
+						t.Errorf("%#v: MulSlice(%d): [%d] expected %d, got %d", field, c, i, expect, out[i])
+					}
+				}
+				copy(out, orig)
+				field.MulAddSlice(byte(c), in, out)
+				for i := range in {
+					expect := field.Add(orig[i], field.Mul(byte(c), in[i]))
+					if out[i] != expect {
+						t.Errorf("%#v: MulAddSlice(%d): [%d] expected %d, got %d", field, c, i, expect, out[i])
+					}
+				}
+			}
+			out := make([]byte, size)
+			prng.Read(out)
+			orig := append([]byte(nil), out...)
+			field.AddSlice(in, out)
+			for i := range in {
+				if expect := field.Add(orig[i], in[i]); out[i] != expect {
+					t.Errorf("%#v: AddSlice: [%d] expected %d, got %d", field, i, expect, out[i])
+				}
+			}
+		}
+	}
+}
+
+func TestGF_MulSlice_short_output(t *testing.T) {
+	for _, f := range []func(){
+		func() { Default.MulSlice(3, make([]byte, 4), make([]byte, 3)) },
+		func() { Default.MulAddSlice(3, make([]byte, 4), make([]byte, 3)) },
+		func() { Default.AddSlice(make([]byte, 4), make([]byte, 3)) },
+	} {
+		e := panicValue(f)
+		if e != ErrSliceLength {
+			t.Errorf("expected panic(ErrSliceLength), got %v", e)
+		}
+	}
+}
+
+func benchmarkMulAddSlice(b *testing.B, size int) {
+	in := make([]byte, size)
+	out := make([]byte, size)
+	rand.New(rand.NewSource(42)).Read(in)
+	b.SetBytes(int64(size))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Default.MulAddSlice(0x8e, in, out)
+	}
+}
+
+func BenchmarkGF_MulAddSlice_1K(b *testing.B)  { benchmarkMulAddSlice(b, 1<<10) }
+func BenchmarkGF_MulAddSlice_64K(b *testing.B) { benchmarkMulAddSlice(b, 1<<16) }
+func BenchmarkGF_MulAddSlice_1M(b *testing.B)  { benchmarkMulAddSlice(b, 1<<20) }
@@ -0,0 +1,56 @@
+package galoisfield
+
+import (
+	"testing"
+)
+
+func TestGeneratedTables(t *testing.T) {
+	for _, wk := range wellknown[1:] {
+		gf := wk.field
+		log, exp, mul, inv := generatedTables(gf.params)
+		if log == nil {
+			t.Errorf("%s: no generated tables; run go generate", wk.name)
+			continue
+		}
+		// The well-known fields use the generated tables directly.
+		if &gf.log[0] != &log[0] || &gf.exp[0] != &exp[0] {
+			t.Errorf("%s: expected the field to use the generated tables", wk.name)
+		}
+		wantLog, wantExp, err := buildTables(gf.params)
+		if err != nil {
+			t.Fatalf("%s: buildTables: %v", wk.name, err)
+		}
+		if !equalBytes(log, wantLog) {
+			t.Errorf("%s: generated log table does not match New:\n\texpected %v\n\tgot      %v", wk.name, wantLog, log)
+		}
+		if !equalBytes(exp, wantExp) {
+			t.Errorf("%s: generated exp table does not match New:\n\texpected %v\n\tgot      %v", wk.name, wantExp, exp)
+		}
+		if gf.k != 8 {
+			if mul != nil || inv != nil {
+				t.Errorf("%s: unexpected generated mul/inv tables", wk.name)
+			}
+			continue
+		}
+		if mul == nil || inv == nil {
+			t.Errorf("%s: missing generated mul/inv tables", wk.name)
+			continue
+		}
+		if gf.MulTable() != mul || gf.InvTable() != inv {
+			t.Errorf("%s: expected MulTable/InvTable to return the generated tables", wk.name)
+		}
+		for x := 0; x < 256; x++ {
+			for y := 0; y < 256; y++ {
+				if expect := mulSlow(byte(x), byte(y), byte(gf.p), gf.k); mul[x][y] != expect {
+					t.Errorf("%s: expected mul[%d][%d]=%d, got %d", wk.name, x, y, expect, mul[x][y])
+				}
+			}
+			if x != 0 && mul[x][inv[x]] != 1 {
+				t.Errorf("%s: expected %d*inv[%[2]d]=1, got inv=%d", wk.name, x, inv[x])
+			}
+		}
+	}
+	if log, exp, mul, inv := generatedTables(New(16, 0x19, 2).params); log != nil || exp != nil || mul != nil || inv != nil {
+		t.Errorf("expected no generated tables for New(16, 0x19, 2)")
+	}
+}